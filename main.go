@@ -1,16 +1,33 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"log"
 	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/zMoooooritz/nachrichten/pkg/config"
+	"github.com/zMoooooritz/nachrichten/pkg/http"
+	"github.com/zMoooooritz/nachrichten/pkg/storage"
 	"github.com/zMoooooritz/nachrichten/pkg/tagesschau"
 	"github.com/zMoooooritz/nachrichten/pkg/tui"
 	"github.com/zMoooooritz/nachrichten/pkg/util"
+	"github.com/zMoooooritz/nachrichten/pkg/watch"
+)
+
+const (
+	cacheRetention   time.Duration = 7 * 24 * time.Hour
+	offlineDirName   string        = "offline"
+	notifiedFileName string        = "notified.json"
+	bookmarksFile    string        = "bookmarks.json"
+	readFileName     string        = "read.json"
+	readRetention    time.Duration = 90 * 24 * time.Hour
 )
 
 var (
@@ -21,6 +38,8 @@ var (
 	configFile = flag.String("config", "", "Path to configuration file")
 	logFile    = flag.String("debug", "", "Path to log file")
 	shortNews  = flag.Bool("shortnews", false, "Only open the current short news")
+	watchNews  = flag.Bool("watch", false, "Run headless and notify about breaking news")
+	verbose    = flag.Bool("verbose", false, "Print the notifications of -watch to the terminal")
 	version    = flag.Bool("version", false, "Display version")
 )
 
@@ -57,8 +76,31 @@ func main() {
 		util.Logger.Println("Application started.")
 	}
 
+	baseURL := configuration.Settings.APIBaseURL
+	if baseURL == "" {
+		baseURL = tagesschau.DefaultBaseURL
+	}
+	retry := configuration.Settings.Retry
+	options := []tagesschau.Option{
+		tagesschau.WithRetryPolicy(http.RetryPolicy{
+			Attempts:       retry.Attempts,
+			InitialBackoff: retry.InitialBackoff,
+			MaxBackoff:     retry.MaxBackoff,
+			StatusCodes:    retry.StatusCodes,
+		}.WithDefaults()),
+	}
+	if configuration.Settings.EnableCache {
+		cache, err := openCache()
+		if err == nil {
+			options = append(options, tagesschau.WithCache(cache))
+		} else {
+			util.Logger.Println("Unable to open the cache: ", err)
+		}
+	}
+	client := tagesschau.NewClient(baseURL, http.NewDoer(configuration.Settings.HTTPTimeout), options...)
+
 	if *shortNews {
-		url, err := tagesschau.GetShortNewsURL()
+		url, err := client.GetShortNewsURL(context.Background())
 		if err == nil {
 			opener := util.NewOpener(configuration.Applications)
 			opener.OpenUrl(util.TypeVideo, url)
@@ -68,11 +110,81 @@ func main() {
 		os.Exit(0)
 	}
 
-	p := tea.NewProgram(tui.InitialModel(configuration),
+	if *watchNews {
+		err := runWatcher(configuration, client)
+		if err != nil {
+			log.Fatalln("Error occoured while watching the news: ", err)
+		}
+		os.Exit(0)
+	}
+
+	var snapshot *storage.Snapshot
+	cacheDir, err := storage.CacheDir()
+	if err == nil {
+		snapshot = storage.NewSnapshot(filepath.Join(cacheDir, offlineDirName))
+	} else {
+		util.Logger.Println("Unable to locate the cache directory: ", err)
+	}
+
+	var (
+		bookmarks *storage.Bookmarks
+		read      *storage.IDSet
+	)
+	dataDir, err := storage.DataDir()
+	if err == nil {
+		bookmarks, err = storage.LoadBookmarks(filepath.Join(dataDir, bookmarksFile))
+		if err != nil {
+			util.Logger.Println("Unable to load the bookmarks: ", err)
+		}
+		read, err = storage.LoadIDSet(filepath.Join(dataDir, readFileName))
+		if err != nil {
+			util.Logger.Println("Unable to load the read articles: ", err)
+		}
+		read.Prune(readRetention)
+	} else {
+		util.Logger.Println("Unable to locate the data directory: ", err)
+	}
+
+	p := tea.NewProgram(tui.InitialModel(configuration, client, snapshot, bookmarks, read),
 		tea.WithAltScreen(),
 	)
-	if _, err := p.Run(); err != nil {
+	_, err = p.Run()
+	if saveErr := read.Save(); saveErr != nil {
+		util.Logger.Println("Unable to save the read articles: ", saveErr)
+	}
+	if err != nil {
 		fmt.Printf("There's been an error: %v", err)
 		os.Exit(1)
 	}
 }
+
+func openCache() (*http.Cache, error) {
+	dir, err := storage.CacheDir()
+	if err != nil {
+		return nil, err
+	}
+	cache, err := http.NewCache(dir)
+	if err != nil {
+		return nil, err
+	}
+	go cache.Prune(cacheRetention)
+	return cache, nil
+}
+
+func runWatcher(configuration config.Configuration, client *tagesschau.Client) error {
+	dataDir, err := storage.DataDir()
+	if err != nil {
+		return err
+	}
+	notified, err := storage.LoadIDSet(filepath.Join(dataDir, notifiedFileName))
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	notifier := util.NewNotifier(configuration.Applications.Notification)
+	watcher := watch.NewWatcher(client, notifier, notified, configuration.Settings.Watch, configuration.Settings.Language, *verbose)
+	return watcher.Run(ctx)
+}
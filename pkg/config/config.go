@@ -3,6 +3,7 @@ package config
 import (
 	"fmt"
 	"os"
+	"time"
 
 	"gopkg.in/yaml.v3"
 )
@@ -15,9 +16,35 @@ type Configuration struct {
 }
 
 type Settings struct {
-	HideHelpOnStartup bool    `yaml:"HideHelpOnStartup"`
-	PreloadThumbnails bool    `yaml:"PreloadThumbnails"`
-	NavigatorWidth    float32 `yaml:"NavigatorWidth"`
+	HideHelpOnStartup bool          `yaml:"HideHelpOnStartup"`
+	PreloadThumbnails bool          `yaml:"PreloadThumbnails"`
+	ImageProtocol     string        `yaml:"ImageProtocol"`
+	ImageMode         string        `yaml:"ImageMode"`
+	ImageColors       string        `yaml:"ImageColors"`
+	NavigatorWidth    float32       `yaml:"NavigatorWidth"`
+	Language          string        `yaml:"Language"`
+	APIBaseURL        string        `yaml:"APIBaseURL"`
+	HTTPTimeout       time.Duration `yaml:"HTTPTimeout"`
+	EnableCache       bool          `yaml:"EnableCache"`
+	RefreshInterval   time.Duration `yaml:"RefreshInterval"`
+	BreakingNewsBell  bool          `yaml:"BreakingNewsBell"`
+	Tabs              []string      `yaml:"Tabs"`
+	Regions           []int         `yaml:"Regions"`
+	Retry             Retry         `yaml:"Retry"`
+	Watch             Watch         `yaml:"Watch"`
+}
+
+type Retry struct {
+	Attempts       int           `yaml:"Attempts"`
+	InitialBackoff time.Duration `yaml:"InitialBackoff"`
+	MaxBackoff     time.Duration `yaml:"MaxBackoff"`
+	StatusCodes    []int         `yaml:"StatusCodes"`
+}
+
+type Watch struct {
+	Interval time.Duration `yaml:"Interval"`
+	Breaking bool          `yaml:"Breaking"`
+	Regions  []int         `yaml:"Regions"`
 }
 
 type Keys struct {
@@ -42,6 +69,18 @@ type Keys struct {
 	OpenArticle   []string `yaml:"OpenArticle"`
 	OpenVideo     []string `yaml:"OpenVideo"`
 	OpenShortNews []string `yaml:"OpenShortNews"`
+	ShowBreaking  []string `yaml:"ShowBreaking"`
+	SelectRegions []string `yaml:"SelectRegions"`
+	FollowLink    []string `yaml:"FollowLink"`
+	Back          []string `yaml:"Back"`
+	Forward       []string `yaml:"Forward"`
+	Bookmark      []string `yaml:"Bookmark"`
+	NextUnread    []string `yaml:"NextUnread"`
+	MarkAllRead   []string `yaml:"MarkAllRead"`
+	FindNext      []string `yaml:"FindNext"`
+	FindPrev      []string `yaml:"FindPrev"`
+	ZoomIn        []string `yaml:"ZoomIn"`
+	ZoomOut       []string `yaml:"ZoomOut"`
 	Help          []string `yaml:"Help"`
 }
 
@@ -57,10 +96,11 @@ type Theme struct {
 }
 
 type Applications struct {
-	Image Application `yaml:"Image"`
-	Audio Application `yaml:"Audio"`
-	Video Application `yaml:"Video"`
-	HTML  Application `yaml:"HTML"`
+	Image        Application `yaml:"Image"`
+	Audio        Application `yaml:"Audio"`
+	Video        Application `yaml:"Video"`
+	HTML         Application `yaml:"HTML"`
+	Notification Application `yaml:"Notification"`
 }
 
 type Application struct {
@@ -93,7 +133,26 @@ func defaultConfiguration() Configuration {
 		Settings: Settings{
 			HideHelpOnStartup: false,
 			PreloadThumbnails: false,
+			ImageProtocol:     "auto",
+			ImageMode:         "ascii",
+			ImageColors:       "auto",
 			NavigatorWidth:    0.3,
+			HTTPTimeout:       2 * time.Second,
+			EnableCache:       true,
+			RefreshInterval:   10 * time.Minute,
+			Tabs:              []string{"national", "regional", "gemerkt", "suche"},
+			Regions:           []int{},
+			Retry: Retry{
+				Attempts:       3,
+				InitialBackoff: 500 * time.Millisecond,
+				MaxBackoff:     5 * time.Second,
+				StatusCodes:    []int{408, 429, 500, 502, 503, 504},
+			},
+			Watch: Watch{
+				Interval: 5 * time.Minute,
+				Breaking: true,
+				Regions:  []int{},
+			},
 		},
 		Keys:         defaultKeys(),
 		Applications: Applications{},
@@ -124,6 +183,18 @@ func defaultKeys() Keys {
 		OpenArticle:   []string{"o"},
 		OpenVideo:     []string{"v"},
 		OpenShortNews: []string{"s"},
+		ShowBreaking:  []string{"e"},
+		SelectRegions: []string{"r"},
+		FollowLink:    []string{"u"},
+		Back:          []string{"[", "alt+left"},
+		Forward:       []string{"]", "alt+right"},
+		Bookmark:      []string{"m"},
+		NextUnread:    []string{"."},
+		MarkAllRead:   []string{"R"},
+		FindNext:      []string{"n"},
+		FindPrev:      []string{"N"},
+		ZoomIn:        []string{"+"},
+		ZoomOut:       []string{"-"},
 		Help:          []string{"?"},
 	}
 }
@@ -39,10 +39,20 @@ type Style struct {
 	ItemSelectedTitle lipgloss.Style
 	ItemSelectedDesc  lipgloss.Style
 
+	// The read item state
+	ItemReadTitle lipgloss.Style
+	ItemReadDesc  lipgloss.Style
+
 	// The breaking item state
 	ItemBreakingTitle lipgloss.Style
 	ItemBreakingDesc  lipgloss.Style
 
+	// The breaking news banner
+	NotificationStyle lipgloss.Style
+
+	// Dialogs shown on top of the application
+	DialogStyle lipgloss.Style
+
 	ActiveTabBorder   lipgloss.Border
 	InactiveTabBorder lipgloss.Border
 
@@ -120,10 +130,17 @@ func NewsStyle(t Theme) (s Style) {
 
 	s.ItemSelectedDesc = s.ItemSelectedTitle.Foreground(highlightColor)
 
+	s.ItemReadTitle = s.ItemNormalTitle.Foreground(shadedColor)
+
+	s.ItemReadDesc = s.ItemReadTitle.Faint(true)
+
 	s.ItemBreakingTitle = lipgloss.NewStyle().Foreground(warningColor).Padding(0, 0, 0, 2)
 
 	s.ItemBreakingDesc = s.ItemBreakingTitle.Foreground(warningShadedColor)
 
+	s.NotificationStyle = lipgloss.NewStyle().Background(warningShadedColor).Foreground(primaryColor).Bold(true).Padding(0, 1)
+	s.DialogStyle = lipgloss.NewStyle().Foreground(primaryColor).BorderForeground(highlightColor).Border(lipgloss.RoundedBorder()).Padding(0, 1)
+
 	s.ActiveTabBorder = lipgloss.Border{
 		Top:         "─",
 		Bottom:      " ",
@@ -0,0 +1,7 @@
+//go:build !unix
+
+package graphics
+
+func terminalCellSize() (int, int, bool) {
+	return 0, 0, false
+}
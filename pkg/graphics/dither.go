@@ -0,0 +1,95 @@
+package graphics
+
+import (
+	"image"
+	"image/color"
+)
+
+// Dither maps the image to the palette, the quantisation error is distributed to the
+// neighbouring pixels following Floyd–Steinberg
+func Dither(img image.Image, palette color.Palette) *image.Paletted {
+	return toPaletted(img, palette, true)
+}
+
+// Nearest maps every pixel of the image to the closest colour of the palette
+func Nearest(img image.Image, palette color.Palette) *image.Paletted {
+	return toPaletted(img, palette, false)
+}
+
+func toPaletted(img image.Image, palette color.Palette, diffuse bool) *image.Paletted {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	paletted := image.NewPaletted(image.Rect(0, 0, width, height), palette)
+	nearest := newNearestColor(palette)
+
+	// the errors of the current and of the next row
+	current := make([][3]int32, width+2)
+	next := make([][3]int32, width+2)
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			r, g, b, _ := img.At(bounds.Min.X+x, bounds.Min.Y+y).RGBA()
+			pixel := [3]int32{
+				clamp(int32(r>>8) + current[x+1][0]/16),
+				clamp(int32(g>>8) + current[x+1][1]/16),
+				clamp(int32(b>>8) + current[x+1][2]/16),
+			}
+
+			index := nearest.index(pixel)
+			paletted.Pix[y*paletted.Stride+x] = index
+			if !diffuse {
+				continue
+			}
+			pr, pg, pb, _ := palette[index].RGBA()
+			quantised := [3]int32{int32(pr >> 8), int32(pg >> 8), int32(pb >> 8)}
+			for c := range pixel {
+				diff := pixel[c] - quantised[c]
+				current[x+2][c] += diff * 7
+				next[x][c] += diff * 3
+				next[x+1][c] += diff * 5
+				next[x+2][c] += diff
+			}
+		}
+		current, next = next, current
+		clear(next)
+	}
+	return paletted
+}
+
+func clamp(value int32) int32 {
+	return max(0, min(value, 255))
+}
+
+// nearestColor finds the closest colour of a palette, the results are cached
+// for colours with a reduced precision of five bits per channel
+type nearestColor struct {
+	palette [][3]int32
+	cache   map[int32]uint8
+}
+
+func newNearestColor(palette color.Palette) *nearestColor {
+	n := &nearestColor{cache: make(map[int32]uint8)}
+	for _, c := range palette {
+		r, g, b, _ := c.RGBA()
+		n.palette = append(n.palette, [3]int32{int32(r >> 8), int32(g >> 8), int32(b >> 8)})
+	}
+	return n
+}
+
+func (n *nearestColor) index(pixel [3]int32) uint8 {
+	key := pixel[0]>>3<<10 | pixel[1]>>3<<5 | pixel[2]>>3
+	if index, found := n.cache[key]; found {
+		return index
+	}
+
+	best, bestDistance := 0, int32(-1)
+	for i, c := range n.palette {
+		dr, dg, db := pixel[0]-c[0], pixel[1]-c[1], pixel[2]-c[2]
+		// weight the channels by the sensitivity of the eye
+		distance := 2*dr*dr + 4*dg*dg + 3*db*db
+		if bestDistance < 0 || distance < bestDistance {
+			best, bestDistance = i, distance
+		}
+	}
+	n.cache[key] = uint8(best)
+	return uint8(best)
+}
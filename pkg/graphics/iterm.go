@@ -0,0 +1,26 @@
+package graphics
+
+import (
+	"fmt"
+	"image"
+)
+
+// ITermRenderer draws images with the inline image protocol of iTerm2, which is also understood by WezTerm
+type ITermRenderer struct{}
+
+func (i ITermRenderer) Resolution(width, height int) (int, int) {
+	return pixelResolution(width, height)
+}
+
+func (i ITermRenderer) Render(img image.Image, width, height int) []string {
+	cols, rows := fitCells(img.Bounds(), width, height)
+	if cols == 0 {
+		return nil
+	}
+	payload, err := encodePNG(img)
+	if err != nil {
+		return TextRenderer{}.Render(img, width, height)
+	}
+
+	return overlayRows(cols, rows, fmt.Sprintf("\x1b]1337;File=inline=1;width=%d;height=%d;preserveAspectRatio=1:%s\a", cols, rows, payload))
+}
@@ -0,0 +1,72 @@
+package graphics
+
+import (
+	"fmt"
+	"image"
+	"strings"
+)
+
+const (
+	kittyImageID   = 1
+	kittyChunkSize = 4096
+	kittyCell      = '\U0010EEEE'
+)
+
+// kittyDiacritics encode the row and column of a placeholder cell, the order is defined by the kitty graphics protocol
+var kittyDiacritics = []rune{
+	0x0305, 0x030D, 0x030E, 0x0310, 0x0312, 0x033D, 0x033E, 0x033F, 0x0346, 0x034A,
+	0x034B, 0x034C, 0x0350, 0x0351, 0x0352, 0x0357, 0x035B, 0x0363, 0x0364, 0x0365,
+	0x0366, 0x0367, 0x0368, 0x0369, 0x036A, 0x036B, 0x036C, 0x036D, 0x036E, 0x036F,
+	0x0483, 0x0484, 0x0485, 0x0486, 0x0487, 0x0592, 0x0593, 0x0594, 0x0595, 0x0597,
+	0x0598, 0x0599, 0x059C, 0x059D, 0x059E, 0x059F, 0x05A0, 0x05A1, 0x05A8, 0x05A9,
+	0x05AB, 0x05AC, 0x05AF, 0x05C4, 0x0610, 0x0611, 0x0612, 0x0613, 0x0614, 0x0615,
+	0x0616, 0x0617, 0x0657, 0x0658, 0x0659, 0x065A, 0x065B, 0x065D, 0x065E, 0x06D6,
+	0x06D7, 0x06D8, 0x06D9, 0x06DA, 0x06DB, 0x06DC, 0x06DF, 0x06E0, 0x06E1, 0x06E2,
+	0x06E4, 0x06E7, 0x06E8, 0x06EB, 0x06EC, 0x0730, 0x0732, 0x0733, 0x0735, 0x0736,
+	0x073A, 0x073D, 0x073F, 0x0740, 0x0741, 0x0743, 0x0745, 0x0747, 0x0749, 0x074A,
+}
+
+// KittyRenderer draws images with the graphics protocol of kitty. The image is shown through
+// unicode placeholders, so it is removed like any other text once the area is redrawn.
+type KittyRenderer struct{}
+
+func (k KittyRenderer) Resolution(width, height int) (int, int) {
+	return pixelResolution(width, height)
+}
+
+func (k KittyRenderer) Render(img image.Image, width, height int) []string {
+	cols, rows := fitCells(img.Bounds(), width, min(height, len(kittyDiacritics)))
+	if cols == 0 {
+		return nil
+	}
+	payload, err := encodePNG(img)
+	if err != nil {
+		return TextRenderer{}.Render(img, width, height)
+	}
+
+	// transmitting an image with the same id replaces the previous one
+	var transmit strings.Builder
+	for start := 0; start < len(payload); start += kittyChunkSize {
+		end := min(start+kittyChunkSize, len(payload))
+		more := 0
+		if end < len(payload) {
+			more = 1
+		}
+		if start == 0 {
+			fmt.Fprintf(&transmit, "\x1b_Ga=T,U=1,f=100,q=2,i=%d,c=%d,r=%d,m=%d;%s\x1b\\", kittyImageID, cols, rows, more, payload[start:end])
+		} else {
+			fmt.Fprintf(&transmit, "\x1b_Gm=%d;%s\x1b\\", more, payload[start:end])
+		}
+	}
+
+	lines := make([]string, rows)
+	for row := range lines {
+		// the column of the following cells is derived from the first cell of the row
+		lines[row] = fmt.Sprintf("\x1b[38;5;%dm", kittyImageID) +
+			string([]rune{kittyCell, kittyDiacritics[row], kittyDiacritics[0]}) +
+			strings.Repeat(string(kittyCell), cols-1) +
+			"\x1b[39m"
+	}
+	lines[0] = transmit.String() + lines[0]
+	return lines
+}
@@ -0,0 +1,146 @@
+package graphics
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"image"
+	"image/png"
+	"math"
+	"os"
+	"strings"
+)
+
+// Protocol determines how images are drawn in the terminal
+type Protocol string
+
+const (
+	IP_AUTO  Protocol = "auto"
+	IP_KITTY Protocol = "kitty"
+	IP_ITERM Protocol = "iterm"
+	IP_SIXEL Protocol = "sixel"
+	IP_TEXT  Protocol = "text"
+)
+
+const (
+	// the size of a terminal cell in pixels if the terminal does not report it
+	defaultCellWidth  = 10
+	defaultCellHeight = 20
+)
+
+// Renderer draws an image into an area of at most width x height terminal cells,
+// the result contains one string for every row of cells
+type Renderer interface {
+	Render(img image.Image, width, height int) []string
+	// Resolution returns the number of pixels that can be shown in the given cells
+	Resolution(width, height int) (int, int)
+}
+
+func IsValidProtocol(protocol Protocol) bool {
+	switch protocol {
+	case IP_AUTO, IP_KITTY, IP_ITERM, IP_SIXEL, IP_TEXT:
+		return true
+	}
+	return false
+}
+
+// NewRenderer returns the renderer of the given protocol, the protocol of the terminal is detected for IP_AUTO.
+// Images are drawn as text with the given mode and colour depth if no graphics protocol is available.
+func NewRenderer(protocol Protocol, mode ImageMode, depth ColorDepth) Renderer {
+	if protocol == IP_AUTO {
+		protocol = DetectProtocol()
+	}
+
+	switch protocol {
+	case IP_KITTY:
+		return KittyRenderer{}
+	case IP_ITERM:
+		return ITermRenderer{}
+	case IP_SIXEL:
+		return SixelRenderer{}
+	default:
+		return NewTextRenderer(mode, depth)
+	}
+}
+
+// DetectProtocol guesses the image protocol supported by the terminal from its environment
+func DetectProtocol() Protocol {
+	term := strings.ToLower(os.Getenv("TERM"))
+	program := strings.ToLower(os.Getenv("TERM_PROGRAM"))
+
+	switch {
+	case os.Getenv("TMUX") != "" || strings.HasPrefix(term, "screen"):
+		// terminal multiplexers do not pass the images through
+		return IP_TEXT
+	case os.Getenv("KITTY_WINDOW_ID") != "", strings.Contains(term, "kitty"), strings.Contains(term, "ghostty"), program == "ghostty":
+		return IP_KITTY
+	case program == "iterm.app", program == "wezterm", os.Getenv("LC_TERMINAL") == "iTerm2":
+		return IP_ITERM
+	case strings.HasPrefix(term, "foot"), strings.HasPrefix(term, "mlterm"), strings.Contains(term, "sixel"),
+		program == "mintty", os.Getenv("KONSOLE_VERSION") != "":
+		return IP_SIXEL
+	}
+	return IP_TEXT
+}
+
+// CellSize returns the size of a terminal cell in pixels
+func CellSize() (int, int) {
+	if width, height, ok := terminalCellSize(); ok {
+		return width, height
+	}
+	return defaultCellWidth, defaultCellHeight
+}
+
+// pixelResolution is the resolution of renderers that draw actual pixels
+func pixelResolution(width, height int) (int, int) {
+	cellWidth, cellHeight := CellSize()
+	return width * cellWidth, height * cellHeight
+}
+
+// fitCells returns the number of columns and rows the image occupies when it is scaled
+// to fit into width x height cells while keeping its aspect ratio
+func fitCells(bounds image.Rectangle, width, height int) (int, int) {
+	if bounds.Dx() <= 0 || bounds.Dy() <= 0 || width <= 0 || height <= 0 {
+		return 0, 0
+	}
+
+	cellWidth, cellHeight := CellSize()
+	ratio := float64(bounds.Dy()) / float64(bounds.Dx()) * float64(cellWidth) / float64(cellHeight)
+	cols := width
+	rows := int(math.Round(float64(cols) * ratio))
+	if rows > height {
+		rows = height
+		cols = int(math.Round(float64(rows) / ratio))
+	}
+	return max(min(cols, width), 1), max(rows, 1)
+}
+
+func encodePNG(img image.Image) (string, error) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(buf.Bytes()), nil
+}
+
+// blankRows returns rows of spaces that reserve the area of an image
+func blankRows(cols, rows int) []string {
+	lines := make([]string, rows)
+	for i := range lines {
+		lines[i] = strings.Repeat(" ", cols)
+	}
+	return lines
+}
+
+// overlayRows reserves the area of an image that is drawn by the given escape sequence.
+// The image is drawn after its area has been cleared, hence it is emitted at the end
+// of the last row with the cursor moved to the first one.
+func overlayRows(cols, rows int, sequence string) []string {
+	lines := blankRows(cols, rows)
+	move := fmt.Sprintf("\x1b[%dD", cols)
+	if rows > 1 {
+		move += fmt.Sprintf("\x1b[%dA", rows-1)
+	}
+	lines[rows-1] += "\x1b7" + move + sequence + "\x1b8"
+	return lines
+}
@@ -0,0 +1,207 @@
+package graphics
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"io"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/nfnt/resize"
+)
+
+const (
+	sixelColors   = 256
+	sixelBandSize = 6
+	// maxSamples limits the number of pixels considered for the palette
+	maxSamples = 4096
+)
+
+// SixelRenderer draws images as sixel graphics, which are supported by foot, mlterm, Konsole, xterm and others
+type SixelRenderer struct{}
+
+func (s SixelRenderer) Resolution(width, height int) (int, int) {
+	return pixelResolution(width, height)
+}
+
+func (s SixelRenderer) Render(img image.Image, width, height int) []string {
+	cols, rows := fitCells(img.Bounds(), width, height)
+	if cols == 0 {
+		return nil
+	}
+
+	// scale the image to the pixels of its cells, sixels are drawn in bands of six pixels
+	cellWidth, cellHeight := CellSize()
+	scale := min(float64(cols*cellWidth)/float64(img.Bounds().Dx()), float64(rows*cellHeight)/float64(img.Bounds().Dy()))
+	w := max(int(float64(img.Bounds().Dx())*scale), 1)
+	h := max(int(float64(img.Bounds().Dy())*scale)/sixelBandSize*sixelBandSize, sixelBandSize)
+	img = resize.Resize(uint(w), uint(h), img, resize.Lanczos3)
+
+	var sixel strings.Builder
+	if err := EncodeSixel(&sixel, img); err != nil {
+		return TextRenderer{}.Render(img, width, height)
+	}
+
+	return overlayRows(cols, rows, sixel.String())
+}
+
+// EncodeSixel writes the image as sixel graphic, the colours are reduced
+// to a palette of the image and the image is dithered to this palette
+func EncodeSixel(w io.Writer, img image.Image) error {
+	bounds := img.Bounds()
+	if bounds.Empty() {
+		return fmt.Errorf("empty image")
+	}
+
+	palette := Quantize(img, sixelColors)
+	paletted := Dither(img, palette)
+
+	var out strings.Builder
+	// P2=1 leaves pixels without colour untouched, the raster attributes set a pixel aspect ratio of 1:1
+	fmt.Fprintf(&out, "\x1bP0;1;0q\"1;1;%d;%d", bounds.Dx(), bounds.Dy())
+	for i, c := range palette {
+		r, g, b, _ := c.RGBA()
+		fmt.Fprintf(&out, "#%d;2;%d;%d;%d", i, percent(r), percent(g), percent(b))
+	}
+
+	width := paletted.Bounds().Dx()
+	height := paletted.Bounds().Dy()
+	bits := make([][]byte, len(palette))
+	for top := 0; top < height; top += sixelBandSize {
+		// collect the sixels of every colour in this band
+		var used []int
+		for y := top; y < min(top+sixelBandSize, height); y++ {
+			for x := 0; x < width; x++ {
+				index := paletted.ColorIndexAt(x, y)
+				if bits[index] == nil {
+					bits[index] = make([]byte, width)
+					used = append(used, int(index))
+				}
+				bits[index][x] |= 1 << (y - top)
+			}
+		}
+
+		sort.Ints(used)
+		for n, index := range used {
+			if n > 0 {
+				out.WriteByte('$')
+			}
+			fmt.Fprintf(&out, "#%d", index)
+			writeSixels(&out, bits[index])
+			bits[index] = nil
+		}
+		out.WriteByte('-')
+	}
+	out.WriteString("\x1b\\")
+
+	_, err := io.WriteString(w, out.String())
+	return err
+}
+
+// writeSixels writes a row of sixels, repetitions are run-length encoded
+func writeSixels(out *strings.Builder, row []byte) {
+	for x := 0; x < len(row); {
+		run := 1
+		for x+run < len(row) && row[x+run] == row[x] {
+			run++
+		}
+		char := rune('?' + row[x])
+		if run > 3 {
+			fmt.Fprintf(out, "!%d%c", run, char)
+		} else {
+			out.WriteString(strings.Repeat(string(char), run))
+		}
+		x += run
+	}
+}
+
+func percent(value uint32) int {
+	return int(math.Round(float64(value) * 100 / 0xffff))
+}
+
+// colorBox is a set of colours of the median cut
+type colorBox []color.RGBA
+
+// channelRange returns the channel with the largest spread of values and its spread
+func (b colorBox) channelRange() (int, int) {
+	low := [3]uint8{255, 255, 255}
+	high := [3]uint8{}
+	for _, c := range b {
+		for i, v := range [3]uint8{c.R, c.G, c.B} {
+			low[i] = min(low[i], v)
+			high[i] = max(high[i], v)
+		}
+	}
+	channel := 0
+	for i := range high {
+		if int(high[i])-int(low[i]) > int(high[channel])-int(low[channel]) {
+			channel = i
+		}
+	}
+	return channel, int(high[channel]) - int(low[channel])
+}
+
+func (b colorBox) average() color.RGBA {
+	var r, g, bl int
+	for _, c := range b {
+		r += int(c.R)
+		g += int(c.G)
+		bl += int(c.B)
+	}
+	n := len(b)
+	return color.RGBA{R: uint8(r / n), G: uint8(g / n), B: uint8(bl / n), A: 255}
+}
+
+// Quantize determines a palette of at most size colours for the image by the median cut algorithm
+func Quantize(img image.Image, size int) color.Palette {
+	bounds := img.Bounds()
+	step := max(int(math.Sqrt(float64(bounds.Dx()*bounds.Dy())/maxSamples)), 1)
+
+	var samples colorBox
+	for y := bounds.Min.Y; y < bounds.Max.Y; y += step {
+		for x := bounds.Min.X; x < bounds.Max.X; x += step {
+			samples = append(samples, color.RGBAModel.Convert(img.At(x, y)).(color.RGBA))
+		}
+	}
+
+	boxes := []colorBox{samples}
+	for len(boxes) < size {
+		// split the box with the largest spread of colours at its median
+		split, spread, channel := -1, 0, 0
+		for i, box := range boxes {
+			c, s := box.channelRange()
+			if s > spread && len(box) > 1 {
+				split, spread, channel = i, s, c
+			}
+		}
+		if split < 0 {
+			break
+		}
+
+		box := boxes[split]
+		value := func(i int) uint8 {
+			return [3]uint8{box[i].R, box[i].G, box[i].B}[channel]
+		}
+		sort.Slice(box, func(i, j int) bool {
+			return value(i) < value(j)
+		})
+		// equal colours must not end up in different boxes
+		median := len(box) / 2
+		for median < len(box) && value(median) == value(median-1) {
+			median++
+		}
+		if median == len(box) {
+			median = sort.Search(len(box), func(i int) bool { return value(i) == value(len(box)-1) })
+		}
+		boxes[split] = box[:median]
+		boxes = append(boxes, box[median:])
+	}
+
+	palette := make(color.Palette, 0, len(boxes))
+	for _, box := range boxes {
+		palette = append(palette, box.average())
+	}
+	return palette
+}
@@ -0,0 +1,124 @@
+package graphics
+
+import (
+	"flag"
+	"image"
+	"image/color"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "update the golden files")
+
+func solidImage() image.Image {
+	img := image.NewRGBA(image.Rect(0, 0, 8, 6))
+	for y := 0; y < 6; y++ {
+		for x := 0; x < 8; x++ {
+			img.Set(x, y, color.RGBA{R: 0x45, G: 0x85, B: 0x88, A: 0xff})
+		}
+	}
+	return img
+}
+
+func gradientImage() image.Image {
+	img := image.NewRGBA(image.Rect(0, 0, 16, 12))
+	for y := 0; y < 12; y++ {
+		for x := 0; x < 16; x++ {
+			img.Set(x, y, color.RGBA{R: uint8(x * 16), G: uint8(y * 20), B: uint8(255 - x*16), A: 0xff})
+		}
+	}
+	return img
+}
+
+func checkerboardImage() image.Image {
+	img := image.NewRGBA(image.Rect(0, 0, 8, 8))
+	for y := 0; y < 8; y++ {
+		for x := 0; x < 8; x++ {
+			if (x+y)%2 == 0 {
+				img.Set(x, y, color.White)
+			} else {
+				img.Set(x, y, color.Black)
+			}
+		}
+	}
+	return img
+}
+
+func TestEncodeSixel(t *testing.T) {
+	tests := []struct {
+		name string
+		img  image.Image
+	}{
+		{"solid", solidImage()},
+		{"gradient", gradientImage()},
+		{"checkerboard", checkerboardImage()},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var out strings.Builder
+			if err := EncodeSixel(&out, test.img); err != nil {
+				t.Fatal(err)
+			}
+
+			golden := filepath.Join("testdata", test.name+".six")
+			if *update {
+				if err := os.WriteFile(golden, []byte(out.String()), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			expected, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if out.String() != string(expected) {
+				t.Errorf("encoded output differs from %s\ngot:  %q\nwant: %q", golden, out.String(), expected)
+			}
+		})
+	}
+}
+
+func TestEncodeSixelEmptyImage(t *testing.T) {
+	var out strings.Builder
+	if err := EncodeSixel(&out, image.NewRGBA(image.Rect(0, 0, 0, 0))); err == nil {
+		t.Error("expected an error for an empty image")
+	}
+}
+
+func TestQuantize(t *testing.T) {
+	tests := []struct {
+		name     string
+		img      image.Image
+		size     int
+		distinct int
+	}{
+		{"solid", solidImage(), 256, 1},
+		{"checkerboard", checkerboardImage(), 256, 2},
+		{"checkerboard reduced", checkerboardImage(), 1, 1},
+		{"gradient", gradientImage(), 256, 192},
+		{"gradient reduced", gradientImage(), 16, 16},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			palette := Quantize(test.img, test.size)
+			if len(palette) == 0 || len(palette) > test.size {
+				t.Fatalf("palette has %d colours, expected between 1 and %d", len(palette), test.size)
+			}
+
+			// every box holds different colours, hence no colour may appear twice
+			seen := make(map[color.Color]bool)
+			for _, c := range palette {
+				if seen[c] {
+					t.Errorf("colour %v appears more than once", c)
+				}
+				seen[c] = true
+			}
+			if len(palette) != test.distinct {
+				t.Errorf("palette has %d colours, expected %d", len(palette), test.distinct)
+			}
+		})
+	}
+}
@@ -0,0 +1,305 @@
+package graphics
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"os"
+	"strings"
+
+	"github.com/nfnt/resize"
+	"github.com/zMoooooritz/nachrichten/pkg/util"
+)
+
+// ImageMode determines the characters used to draw images as text
+type ImageMode string
+
+const (
+	IM_ASCII     ImageMode = "ascii"
+	IM_HALFBLOCK ImageMode = "halfblock"
+	IM_BRAILLE   ImageMode = "braille"
+)
+
+// ColorDepth is the number of colours available to images drawn as text
+type ColorDepth string
+
+const (
+	CD_AUTO      ColorDepth = "auto"
+	CD_TRUECOLOR ColorDepth = "truecolor"
+	CD_256       ColorDepth = "256"
+	CD_16        ColorDepth = "16"
+)
+
+const (
+	upperHalfBlock = '▀'
+	brailleBase    = 0x2800
+	resetColor     = "\x1b[0m"
+)
+
+// brailleDots are the bits of the dots of a braille character by row and column
+var brailleDots = [4][2]rune{
+	{0x01, 0x08},
+	{0x02, 0x10},
+	{0x04, 0x20},
+	{0x40, 0x80},
+}
+
+func IsValidImageMode(mode ImageMode) bool {
+	switch mode {
+	case IM_ASCII, IM_HALFBLOCK, IM_BRAILLE:
+		return true
+	}
+	return false
+}
+
+func IsValidColorDepth(depth ColorDepth) bool {
+	switch depth {
+	case CD_AUTO, CD_TRUECOLOR, CD_256, CD_16:
+		return true
+	}
+	return false
+}
+
+// DetectColorDepth guesses the number of colours supported by the terminal from its environment
+func DetectColorDepth() ColorDepth {
+	colorTerm := strings.ToLower(os.Getenv("COLORTERM"))
+	term := strings.ToLower(os.Getenv("TERM"))
+
+	switch {
+	case colorTerm == "truecolor" || colorTerm == "24bit":
+		return CD_TRUECOLOR
+	case strings.Contains(term, "256color"):
+		return CD_256
+	}
+	return CD_16
+}
+
+// TextRenderer draws images with characters, it works in every terminal.
+// The zero value draws ASCII characters in true colour.
+type TextRenderer struct {
+	Mode  ImageMode
+	Depth ColorDepth
+}
+
+func NewTextRenderer(mode ImageMode, depth ColorDepth) TextRenderer {
+	if depth == CD_AUTO {
+		depth = DetectColorDepth()
+	}
+	return TextRenderer{Mode: mode, Depth: depth}
+}
+
+func (t TextRenderer) Resolution(width, height int) (int, int) {
+	switch t.Mode {
+	case IM_HALFBLOCK:
+		return width, 2 * height
+	case IM_BRAILLE:
+		return 2 * width, 4 * height
+	default:
+		return width, height
+	}
+}
+
+func (t TextRenderer) Render(img image.Image, width, height int) []string {
+	if width <= 0 || height <= 0 {
+		return nil
+	}
+
+	switch t.Mode {
+	case IM_HALFBLOCK:
+		return t.renderHalfBlocks(img, width, height)
+	case IM_BRAILLE:
+		return t.renderBraille(img, width, height)
+	default:
+		return t.renderAscii(img, width, height)
+	}
+}
+
+func (t TextRenderer) renderAscii(img image.Image, width, height int) []string {
+	var lines []string
+	if t.Depth == "" || t.Depth == CD_TRUECOLOR {
+		for _, row := range util.ImageToAscii(img, uint(width), uint(height), true) {
+			lines = append(lines, strings.Join(row, ""))
+		}
+		return lines
+	}
+
+	chars := util.ImageToAscii(img, uint(width), uint(height), false)
+	if len(chars) == 0 {
+		return nil
+	}
+	colors := newCellColors(resize.Resize(uint(len(chars[0])), uint(len(chars)), img, resize.Lanczos3), t.Depth, true)
+	for y, row := range chars {
+		var line strings.Builder
+		for x, char := range row {
+			line.WriteString(colors.code(x, y, false) + char)
+		}
+		lines = append(lines, line.String()+resetColor)
+	}
+	return lines
+}
+
+// renderHalfBlocks draws two pixels per cell, the upper one as foreground and the lower one as background
+func (t TextRenderer) renderHalfBlocks(img image.Image, width, height int) []string {
+	cols, rows := fitCells(img.Bounds(), width, height)
+	if cols == 0 {
+		return nil
+	}
+	colors := newCellColors(resize.Resize(uint(cols), uint(rows*2), img, resize.Lanczos3), t.Depth, true)
+
+	lines := make([]string, rows)
+	for y := range lines {
+		var line strings.Builder
+		for x := 0; x < cols; x++ {
+			line.WriteString(colors.code(x, 2*y, false) + colors.code(x, 2*y+1, true) + string(upperHalfBlock))
+		}
+		lines[y] = line.String() + resetColor
+	}
+	return lines
+}
+
+// renderBraille draws 2x4 dots per cell, the dots are set by the dithered brightness of the pixels
+// and the cell is coloured by the average colour of its set dots
+func (t TextRenderer) renderBraille(img image.Image, width, height int) []string {
+	cols, rows := fitCells(img.Bounds(), width, height)
+	if cols == 0 {
+		return nil
+	}
+	img = resize.Resize(uint(cols*2), uint(rows*4), img, resize.Lanczos3)
+	gray := image.NewGray(image.Rect(0, 0, img.Bounds().Dx(), img.Bounds().Dy()))
+	for y := 0; y < gray.Bounds().Dy(); y++ {
+		for x := 0; x < gray.Bounds().Dx(); x++ {
+			gray.Set(x, y, img.At(img.Bounds().Min.X+x, img.Bounds().Min.Y+y))
+		}
+	}
+	dots := Dither(gray, color.Palette{color.Black, color.White})
+
+	cellColors := image.NewRGBA(image.Rect(0, 0, cols, rows))
+	chars := make([][]rune, rows)
+	for y := range chars {
+		chars[y] = make([]rune, cols)
+		for x := range chars[y] {
+			var r, g, b, set uint32
+			char := rune(brailleBase)
+			for dy, row := range brailleDots {
+				for dx, bit := range row {
+					px, py := 2*x+dx, 4*y+dy
+					if dots.ColorIndexAt(px, py) == 0 {
+						continue
+					}
+					char |= bit
+					pr, pg, pb, _ := img.At(img.Bounds().Min.X+px, img.Bounds().Min.Y+py).RGBA()
+					r, g, b, set = r+pr>>8, g+pg>>8, b+pb>>8, set+1
+				}
+			}
+			chars[y][x] = char
+			if set > 0 {
+				cellColors.Set(x, y, color.RGBA{R: uint8(r / set), G: uint8(g / set), B: uint8(b / set), A: 255})
+			}
+		}
+	}
+
+	// the colours of the cells are not dithered as the dots already are
+	colors := newCellColors(cellColors, t.Depth, false)
+	lines := make([]string, rows)
+	for y := range lines {
+		var line strings.Builder
+		for x := 0; x < cols; x++ {
+			line.WriteString(colors.code(x, y, false) + string(chars[y][x]))
+		}
+		lines[y] = line.String() + resetColor
+	}
+	return lines
+}
+
+// cellColors provides the escape sequences selecting the colours of the pixels of an image,
+// the colours are reduced to the palette of the terminal if it does not support true colour
+type cellColors struct {
+	img      image.Image
+	depth    ColorDepth
+	paletted *image.Paletted
+}
+
+func newCellColors(img image.Image, depth ColorDepth, dither bool) cellColors {
+	colors := cellColors{img: img, depth: depth}
+
+	var palette color.Palette
+	switch depth {
+	case CD_256:
+		palette = palette256
+	case CD_16:
+		palette = palette16
+	default:
+		return colors
+	}
+	if dither {
+		colors.paletted = Dither(img, palette)
+	} else {
+		colors.paletted = Nearest(img, palette)
+	}
+	return colors
+}
+
+func (c cellColors) code(x, y int, background bool) string {
+	layer := 38
+	if background {
+		layer = 48
+	}
+
+	switch c.depth {
+	case CD_256:
+		// the palette omits the 16 system colours, which differ between terminals
+		return fmt.Sprintf("\x1b[%d;5;%dm", layer, 16+int(c.paletted.ColorIndexAt(x, y)))
+	case CD_16:
+		index := int(c.paletted.ColorIndexAt(x, y))
+		base := 30
+		if background {
+			base = 40
+		}
+		if index >= 8 {
+			base += 60
+			index -= 8
+		}
+		return fmt.Sprintf("\x1b[%dm", base+index)
+	default:
+		r, g, b, _ := c.img.At(c.img.Bounds().Min.X+x, c.img.Bounds().Min.Y+y).RGBA()
+		return fmt.Sprintf("\x1b[%d;2;%d;%d;%dm", layer, r>>8, g>>8, b>>8)
+	}
+}
+
+// palette256 contains the colour cube and the grey ramp of the 256 colour palette
+var palette256 = func() color.Palette {
+	levels := []uint8{0, 95, 135, 175, 215, 255}
+	var palette color.Palette
+	for _, r := range levels {
+		for _, g := range levels {
+			for _, b := range levels {
+				palette = append(palette, color.RGBA{R: r, G: g, B: b, A: 255})
+			}
+		}
+	}
+	for i := 0; i < 24; i++ {
+		grey := uint8(8 + 10*i)
+		palette = append(palette, color.RGBA{R: grey, G: grey, B: grey, A: 255})
+	}
+	return palette
+}()
+
+// palette16 contains the default colours of xterm
+var palette16 = color.Palette{
+	color.RGBA{0x00, 0x00, 0x00, 0xff},
+	color.RGBA{0xcd, 0x00, 0x00, 0xff},
+	color.RGBA{0x00, 0xcd, 0x00, 0xff},
+	color.RGBA{0xcd, 0xcd, 0x00, 0xff},
+	color.RGBA{0x00, 0x00, 0xee, 0xff},
+	color.RGBA{0xcd, 0x00, 0xcd, 0xff},
+	color.RGBA{0x00, 0xcd, 0xcd, 0xff},
+	color.RGBA{0xe5, 0xe5, 0xe5, 0xff},
+	color.RGBA{0x7f, 0x7f, 0x7f, 0xff},
+	color.RGBA{0xff, 0x00, 0x00, 0xff},
+	color.RGBA{0x00, 0xff, 0x00, 0xff},
+	color.RGBA{0xff, 0xff, 0x00, 0xff},
+	color.RGBA{0x5c, 0x5c, 0xff, 0xff},
+	color.RGBA{0xff, 0x00, 0xff, 0xff},
+	color.RGBA{0x00, 0xff, 0xff, 0xff},
+	color.RGBA{0xff, 0xff, 0xff, 0xff},
+}
@@ -0,0 +1,179 @@
+package http
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/zMoooooritz/nachrichten/pkg/util"
+)
+
+const (
+	cacheEntryExt string = ".json"
+	cacheDirMode         = 0755
+)
+
+// Cache is a persistent HTTP cache that stores responses on disk and
+// revalidates them with the server once they became stale.
+// The methods of a nil *Cache are no-ops.
+type Cache struct {
+	dir string
+}
+
+type cacheEntry struct {
+	URL          string    `json:"url"`
+	ContentType  string    `json:"contentType"`
+	ETag         string    `json:"etag"`
+	LastModified string    `json:"lastModified"`
+	Expires      time.Time `json:"expires"`
+	Body         []byte    `json:"body"`
+}
+
+func NewCache(dir string) (*Cache, error) {
+	err := os.MkdirAll(dir, cacheDirMode)
+	if err != nil {
+		return nil, err
+	}
+	return &Cache{dir: dir}, nil
+}
+
+// Prune removes all entries that have not been written for the given duration.
+func (c *Cache) Prune(olderThan time.Duration) {
+	if c == nil {
+		return
+	}
+	files, err := os.ReadDir(c.dir)
+	if err != nil {
+		return
+	}
+	for _, file := range files {
+		info, err := file.Info()
+		if err != nil || file.IsDir() || filepath.Ext(file.Name()) != cacheEntryExt {
+			continue
+		}
+		if time.Since(info.ModTime()) > olderThan {
+			_ = os.Remove(filepath.Join(c.dir, file.Name()))
+		}
+	}
+}
+
+func (c *Cache) path(url string) string {
+	hash := sha256.Sum256([]byte(url))
+	return filepath.Join(c.dir, hex.EncodeToString(hash[:])+cacheEntryExt)
+}
+
+func (c *Cache) load(url string) (cacheEntry, bool) {
+	var entry cacheEntry
+	if c == nil {
+		return entry, false
+	}
+
+	data, err := os.ReadFile(c.path(url))
+	if err != nil {
+		return entry, false
+	}
+	err = json.Unmarshal(data, &entry)
+	if err != nil || entry.URL != url {
+		return entry, false
+	}
+	return entry, true
+}
+
+func (c *Cache) store(entry cacheEntry) {
+	if c == nil {
+		return
+	}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+
+	_ = util.WriteFile(c.path(entry.URL), data)
+}
+
+func newCacheEntry(url string, header http.Header, body []byte) (cacheEntry, bool) {
+	entry := cacheEntry{
+		URL:         url,
+		ContentType: header.Get("Content-Type"),
+		Body:        body,
+	}
+	return entry, entry.update(header)
+}
+
+// update refreshes validators and freshness from the response headers,
+// false is returned if the response must not be stored or could neither be reused nor revalidated.
+func (e *cacheEntry) update(header http.Header) bool {
+	directives := parseCacheControl(header.Get("Cache-Control"))
+	if _, found := directives["no-store"]; found {
+		return false
+	}
+
+	if etag := header.Get("ETag"); etag != "" {
+		e.ETag = etag
+	}
+	if lastModified := header.Get("Last-Modified"); lastModified != "" {
+		e.LastModified = lastModified
+	}
+	e.Expires = expiry(header, directives)
+	return e.ETag != "" || e.LastModified != "" || e.isFresh()
+}
+
+func (e cacheEntry) isFresh() bool {
+	return time.Now().Before(e.Expires)
+}
+
+func (e cacheEntry) setValidators(req *http.Request) {
+	if e.ETag != "" {
+		req.Header.Set("If-None-Match", e.ETag)
+	}
+	if e.LastModified != "" {
+		req.Header.Set("If-Modified-Since", e.LastModified)
+	}
+}
+
+// expiry determines until when a response may be served without revalidation
+func expiry(header http.Header, directives map[string]string) time.Time {
+	now := time.Now()
+	if _, found := directives["no-cache"]; found {
+		return now
+	}
+
+	if value, found := directives["max-age"]; found {
+		maxAge, err := strconv.Atoi(value)
+		if err != nil {
+			return now
+		}
+		age, _ := strconv.Atoi(header.Get("Age"))
+		return now.Add(time.Duration(maxAge-age) * time.Second)
+	}
+
+	if expires, err := http.ParseTime(header.Get("Expires")); err == nil {
+		date, err := http.ParseTime(header.Get("Date"))
+		if err != nil {
+			return expires
+		}
+		// compensate for clock skew between client and server
+		return now.Add(expires.Sub(date))
+	}
+
+	return now
+}
+
+func parseCacheControl(value string) map[string]string {
+	directives := make(map[string]string)
+	for _, part := range strings.Split(value, ",") {
+		name, arg, _ := strings.Cut(strings.TrimSpace(part), "=")
+		if name == "" {
+			continue
+		}
+		directives[strings.ToLower(name)] = strings.Trim(arg, "\"")
+	}
+	return directives
+}
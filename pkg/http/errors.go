@@ -0,0 +1,44 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const (
+	bodySnippetLength int = 200
+)
+
+// StatusError is returned if the server answered with a non-2xx status code.
+type StatusError struct {
+	StatusCode int
+	URL        string
+	Body       string
+	RetryAfter time.Duration
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("unexpected status %d (%s) for %s", e.StatusCode, http.StatusText(e.StatusCode), e.URL)
+}
+
+// ContentTypeError is returned if the response does not carry the expected content type.
+type ContentTypeError struct {
+	ContentType string
+	Expected    string
+	URL         string
+}
+
+func (e *ContentTypeError) Error() string {
+	return fmt.Sprintf("unexpected content type %q (expected %q) for %s", e.ContentType, e.Expected, e.URL)
+}
+
+// BodyTooLargeError is returned if the response body exceeds the size limit.
+type BodyTooLargeError struct {
+	Limit int64
+	URL   string
+}
+
+func (e *BodyTooLargeError) Error() string {
+	return fmt.Sprintf("response body exceeds %d bytes for %s", e.Limit, e.URL)
+}
@@ -1,32 +1,110 @@
 package http
 
 import (
+	"bytes"
+	"context"
 	"image"
 	"io"
+	"mime"
 	"net/http"
+	"strings"
 	"time"
 )
 
 const (
-	httpTimeout time.Duration = 2
-	agentName   string        = "nachrichten-agent"
+	DefaultTimeout time.Duration = 2 * time.Second
+	agentName      string        = "nachrichten-agent"
+	maxBodySize    int64         = 16 << 20
+
+	ContentTypeJSON  string = "application/json"
+	ContentTypeHTML  string = "text/html"
+	ContentTypeImage string = "image/"
 )
 
-var (
-	client http.Client = http.Client{
-		Timeout: time.Second * httpTimeout,
+// Doer is the minimal interface required to execute HTTP requests,
+// it is satisfied by *http.Client and can be replaced for testing.
+type Doer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+type Client struct {
+	doer        Doer
+	userAgent   string
+	retryPolicy RetryPolicy
+	cache       *Cache
+}
+
+func NewClient(doer Doer) *Client {
+	return &Client{
+		doer:        doer,
+		userAgent:   agentName,
+		retryPolicy: DefaultRetryPolicy(),
+	}
+}
+
+func DefaultDoer() Doer {
+	return NewDoer(DefaultTimeout)
+}
+
+func NewDoer(timeout time.Duration) Doer {
+	return &http.Client{
+		Timeout: timeout,
+	}
+}
+
+func (c *Client) SetUserAgent(userAgent string) {
+	c.userAgent = userAgent
+}
+
+func (c *Client) SetRetryPolicy(policy RetryPolicy) {
+	c.retryPolicy = policy
+}
+
+func (c *Client) SetCache(cache *Cache) {
+	c.cache = cache
+}
+
+// FetchURL loads the body of the given url, the response is validated against
+// the expected content type, an empty expected type skips this validation.
+// Transient failures are retried according to the retry policy of the client.
+func (c *Client) FetchURL(ctx context.Context, url string, expectedType string) ([]byte, error) {
+	attempts := max(c.retryPolicy.Attempts, 1)
+	for attempt := 1; ; attempt++ {
+		body, err := c.fetch(ctx, url, expectedType)
+		if err == nil {
+			return body, nil
+		}
+
+		delay, retry := c.retryPolicy.retryDelay(ctx, attempt+1, err)
+		if !retry {
+			return nil, err
+		}
+
+		notifyRetry(ctx, attempt+1, attempts, err)
+		if sleepErr := sleep(ctx, delay); sleepErr != nil {
+			return nil, err
+		}
+	}
+}
+
+func (c *Client) fetch(ctx context.Context, url string, expectedType string) ([]byte, error) {
+	entry, cached := c.cache.load(url)
+	if cached && !matchesContentType(entry.ContentType, expectedType) {
+		cached = false
+	}
+	if cached && entry.isFresh() {
+		return entry.Body, nil
 	}
-)
 
-func FetchURL(url string) ([]byte, error) {
-	req, err := http.NewRequest(http.MethodGet, url, nil)
+	req, err := c.newRequest(ctx, url)
 	if err != nil {
 		return nil, err
 	}
+	if cached {
+		entry.setValidators(req)
+	}
 
-	req.Header.Set("User-Agent", agentName)
-
-	res, err := client.Do(req)
+	res, err := c.doer.Do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -35,35 +113,84 @@ func FetchURL(url string) ([]byte, error) {
 		defer res.Body.Close()
 	}
 
-	body, err := io.ReadAll(res.Body)
+	if cached && res.StatusCode == http.StatusNotModified {
+		if entry.update(res.Header) {
+			c.cache.store(entry)
+		}
+		return entry.Body, nil
+	}
+
+	body, err := readBody(res, url, expectedType)
 	if err != nil {
 		return nil, err
 	}
 
+	if entry, cacheable := newCacheEntry(url, res.Header, body); cacheable {
+		c.cache.store(entry)
+	}
 	return body, nil
 }
 
-func LoadImage(url string) (image.Image, error) {
-	req, err := http.NewRequest(http.MethodGet, url, nil)
+func (c *Client) LoadImage(ctx context.Context, url string) (image.Image, error) {
+	body, err := c.FetchURL(ctx, url, ContentTypeImage)
 	if err != nil {
 		return nil, err
 	}
 
-	req.Header.Set("User-Agent", agentName)
+	img, _, err := image.Decode(bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	return img, nil
+}
 
-	res, err := client.Do(req)
+func (c *Client) newRequest(ctx context.Context, url string) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	if res.Body != nil {
-		defer res.Body.Close()
+	req.Header.Set("User-Agent", c.userAgent)
+
+	return req, nil
+}
+
+func readBody(res *http.Response, url string, expectedType string) ([]byte, error) {
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		snippet, _ := io.ReadAll(io.LimitReader(res.Body, int64(bodySnippetLength)))
+		return nil, &StatusError{
+			StatusCode: res.StatusCode,
+			URL:        url,
+			Body:       string(snippet),
+			RetryAfter: parseRetryAfter(res.Header.Get("Retry-After")),
+		}
+	}
+
+	contentType := res.Header.Get("Content-Type")
+	if !matchesContentType(contentType, expectedType) {
+		return nil, &ContentTypeError{ContentType: contentType, Expected: expectedType, URL: url}
+	}
+
+	if res.ContentLength > maxBodySize {
+		return nil, &BodyTooLargeError{Limit: maxBodySize, URL: url}
 	}
 
-	img, _, err := image.Decode(res.Body)
+	body, err := io.ReadAll(io.LimitReader(res.Body, maxBodySize+1))
 	if err != nil {
 		return nil, err
 	}
+	if int64(len(body)) > maxBodySize {
+		return nil, &BodyTooLargeError{Limit: maxBodySize, URL: url}
+	}
 
-	return img, nil
+	return body, nil
+}
+
+func matchesContentType(contentType string, expectedType string) bool {
+	if expectedType == "" {
+		return true
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	return err == nil && strings.HasPrefix(mediaType, expectedType)
 }
@@ -0,0 +1,154 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+type RetryPolicy struct {
+	// Attempts is the total number of attempts, a value below 2 disables retries
+	Attempts       int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	StatusCodes    []int
+}
+
+// WithDefaults fills the unset fields of the policy with those of the default policy
+func (p RetryPolicy) WithDefaults() RetryPolicy {
+	defaults := DefaultRetryPolicy()
+	if p.Attempts == 0 {
+		p.Attempts = defaults.Attempts
+	}
+	if p.InitialBackoff == 0 {
+		p.InitialBackoff = defaults.InitialBackoff
+	}
+	if p.MaxBackoff == 0 {
+		p.MaxBackoff = defaults.MaxBackoff
+	}
+	if p.StatusCodes == nil {
+		p.StatusCodes = defaults.StatusCodes
+	}
+	return p
+}
+
+// RetryNotifier is called before a failed request is attempted again.
+type RetryNotifier func(attempt, attempts int, err error)
+
+type retryNotifierKey struct{}
+
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		Attempts:       3,
+		InitialBackoff: 500 * time.Millisecond,
+		MaxBackoff:     5 * time.Second,
+		StatusCodes: []int{
+			http.StatusRequestTimeout,
+			http.StatusTooManyRequests,
+			http.StatusInternalServerError,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
+	}
+}
+
+// WithRetryNotifier attaches a notifier to the context that is informed about
+// every retry of requests issued with this context.
+func WithRetryNotifier(ctx context.Context, notifier RetryNotifier) context.Context {
+	return context.WithValue(ctx, retryNotifierKey{}, notifier)
+}
+
+func notifyRetry(ctx context.Context, attempt, attempts int, err error) {
+	if notifier, ok := ctx.Value(retryNotifierKey{}).(RetryNotifier); ok {
+		notifier(attempt, attempts, err)
+	}
+}
+
+// retryDelay determines how long to wait before the given attempt, false is
+// returned if the error is not considered transient.
+func (p RetryPolicy) retryDelay(ctx context.Context, attempt int, err error) (time.Duration, bool) {
+	if attempt > p.Attempts || ctx.Err() != nil {
+		return 0, false
+	}
+
+	delay := p.backoff(attempt)
+
+	var statusErr *StatusError
+	var netErr net.Error
+	switch {
+	case errors.As(err, &statusErr):
+		if !p.isRetryableStatus(statusErr.StatusCode) {
+			return 0, false
+		}
+		if statusErr.RetryAfter > 0 {
+			if statusErr.RetryAfter > p.MaxBackoff {
+				// the server asks for more patience than we are willing to spend
+				return 0, false
+			}
+			delay = max(delay, statusErr.RetryAfter)
+		}
+	case errors.As(err, &netErr):
+		// unknown hosts, invalid certificates or unsupported schemes do not go away by trying again
+		if !netErr.Timeout() && !errors.Is(err, syscall.ECONNRESET) && !errors.Is(err, syscall.ECONNREFUSED) {
+			return 0, false
+		}
+	default:
+		return 0, false
+	}
+
+	return delay, true
+}
+
+// backoff grows exponentially with every attempt and applies equal jitter
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	delay := p.InitialBackoff << min(attempt-2, 30)
+	if delay <= 0 || delay > p.MaxBackoff {
+		delay = p.MaxBackoff
+	}
+	if delay <= 0 {
+		return 0
+	}
+	half := delay / 2
+	return half + rand.N(half+1)
+}
+
+func (p RetryPolicy) isRetryableStatus(code int) bool {
+	for _, c := range p.StatusCodes {
+		if c == code {
+			return true
+		}
+	}
+	return false
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// parseRetryAfter supports both the delay-seconds and the HTTP-date format
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(max(seconds, 0)) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(time.Until(date), 0)
+	}
+	return 0
+}
@@ -0,0 +1,110 @@
+package i18n
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/zMoooooritz/nachrichten/pkg/tagesschau"
+)
+
+type Language string
+
+const (
+	GERMAN  Language = "de"
+	ENGLISH Language = "en"
+)
+
+// DetectLanguage returns the configured language, if none is configured
+// the language is taken from the environment and defaults to German
+func DetectLanguage(setting string) Language {
+	if language, ok := parseLanguage(setting); ok {
+		return language
+	}
+	for _, env := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		if language, ok := parseLanguage(os.Getenv(env)); ok {
+			return language
+		}
+	}
+	return GERMAN
+}
+
+// parseLanguage accepts plain language codes as well as locales such as en_US.UTF-8
+func parseLanguage(value string) (Language, bool) {
+	code := strings.ToLower(value)
+	if i := strings.IndexAny(code, "_-.@"); i >= 0 {
+		code = code[:i]
+	}
+	switch Language(code) {
+	case GERMAN, ENGLISH:
+		return Language(code), true
+	}
+	return "", false
+}
+
+// Catalog provides the user facing texts in a single language
+type Catalog struct {
+	language Language
+	messages map[Message]string
+}
+
+func NewCatalog(language Language) Catalog {
+	messages, ok := catalogs[language]
+	if !ok {
+		language = GERMAN
+		messages = catalogs[GERMAN]
+	}
+	return Catalog{
+		language: language,
+		messages: messages,
+	}
+}
+
+func (c Catalog) Language() Language {
+	return c.language
+}
+
+// T returns the text of the message, arguments are formatted into the text
+func (c Catalog) T(message Message, args ...any) string {
+	text, ok := c.messages[message]
+	if !ok {
+		text = catalogs[GERMAN][message]
+	}
+	if len(args) > 0 {
+		return fmt.Sprintf(text, args...)
+	}
+	return text
+}
+
+// RegionName returns the name of the region or an empty string for unknown regions
+func (c Catalog) RegionName(id tagesschau.RegionID) string {
+	names := tagesschau.GERMAN_NAMES
+	if c.language == ENGLISH {
+		names = tagesschau.ENGLISH_NAMES
+	}
+	return string(names[id])
+}
+
+func (c Catalog) RessortName(ressort tagesschau.Ressort) string {
+	if c.language == ENGLISH {
+		if name, ok := englishRessortNames[ressort]; ok {
+			return name
+		}
+	}
+	return tagesschau.RESSORT_NAMES[ressort]
+}
+
+func (c Catalog) MediaName(mediaType tagesschau.MediaType) string {
+	switch mediaType {
+	case tagesschau.MT_VIDEO:
+		return c.T(MediaVideo)
+	case tagesschau.MT_AUDIO:
+		return c.T(MediaAudio)
+	case tagesschau.MT_GALLERY:
+		return c.T(MediaGallery)
+	case tagesschau.MT_SOCIAL:
+		return c.T(MediaSocial)
+	default:
+		return c.T(MediaWebview)
+	}
+}
@@ -0,0 +1,217 @@
+package i18n
+
+import "github.com/zMoooooritz/nachrichten/pkg/tagesschau"
+
+type Message int
+
+const (
+	DateTimeFormat Message = iota
+	TimeFormat
+
+	LoadingNews
+	RetryingNews
+	QuitHint
+	LoadingNewsFailed
+	OfflineSince
+
+	HeaderTitle
+	TabNational
+	TabRegional
+	TabSearch
+	TabBookmarks
+
+	SearchPlaceholder
+	NoResults
+	ResultCount
+	ResultCountAtMost
+	LoadingMore
+	UnknownSortOrder
+	InvalidDate
+	InvalidDateRange
+
+	ModeArticle
+	ModeImage
+	ModeDetails
+	EmptyArticle
+	UnparsableArticle
+	MediaPlaceholder
+	MediaVideo
+	MediaAudio
+	MediaGallery
+	MediaSocial
+	MediaWebview
+	Links
+	LinkMode
+	MediaMode
+
+	DetailTitle
+	DetailSubtitle
+	DetailRegion
+	DetailRegions
+	DetailRessort
+	DetailType
+	DetailBreaking
+	DetailTags
+	DetailRelated
+	Yes
+	No
+
+	BreakingNews
+	OpenHint
+
+	RegionPickerTitle
+	RegionPickerHint
+
+	ErrorStatus
+	ErrorContentType
+	ErrorBodyTooLarge
+	ErrorUnreadable
+	ErrorTimeout
+	ErrorNoConnection
+	ErrorUnknown
+)
+
+var catalogs = map[Language]map[Message]string{
+	GERMAN: {
+		DateTimeFormat: "15:04 02.01.06",
+		TimeFormat:     "15:04",
+
+		LoadingNews:       "Lade Nachrichten...",
+		RetryingNews:      "erneuter Versuch (%d/%d)…",
+		QuitHint:          "q zum Beenden",
+		LoadingNewsFailed: "Laden der Nachrichten fehlgeschlagen: %s",
+		OfflineSince:      "Offline – Stand: %s",
+
+		HeaderTitle:  "Nachrichten",
+		TabNational:  "National",
+		TabRegional:  "Regional",
+		TabSearch:    "Suche",
+		TabBookmarks: "Gemerkt",
+
+		SearchPlaceholder: "Suche ...",
+		NoResults:         "Keine Ergebnisse",
+		ResultCount:       "%d von %d Ergebnissen",
+		ResultCountAtMost: "%d von höchstens %d Ergebnissen",
+		LoadingMore:       "lade ...",
+		UnknownSortOrder:  "unbekannte Sortierung %q",
+		InvalidDate:       "ungültiges Datum %q",
+		InvalidDateRange:  "Zeitraum endet vor dem Beginn",
+
+		ModeArticle:       "Artikel",
+		ModeImage:         "Bild",
+		ModeDetails:       "Details",
+		EmptyArticle:      "LEER",
+		UnparsableArticle: "Artikel konnte nicht dargestellt werden",
+		MediaPlaceholder:  "[%s: %s – Taste %d]",
+		MediaVideo:        "Video",
+		MediaAudio:        "Audio",
+		MediaGallery:      "Bildergalerie",
+		MediaSocial:       "Social Media",
+		MediaWebview:      "Webinhalt",
+		Links:             "Links",
+		LinkMode:          "Link: %s_",
+		MediaMode:         "Medium: %s_",
+
+		DetailTitle:    "Titel",
+		DetailSubtitle: "Untertitel",
+		DetailRegion:   "Region",
+		DetailRegions:  "Regionen",
+		DetailRessort:  "Ressort",
+		DetailType:     "Typ",
+		DetailBreaking: "Eilmeldung",
+		DetailTags:     "Tags",
+		DetailRelated:  "Verwandt",
+		Yes:            "Ja",
+		No:             "Nein",
+
+		BreakingNews: "EILMELDUNG",
+		OpenHint:     "öffnen",
+
+		RegionPickerTitle: "Bundesländer",
+		RegionPickerHint:  "Keine Auswahl zeigt alle Bundesländer, %s übernimmt, %s verwirft",
+
+		ErrorStatus:       "Server antwortete mit Status %d (%s)",
+		ErrorContentType:  "Unerwartete Antwort des Servers (%s)",
+		ErrorBodyTooLarge: "Antwort des Servers ist zu groß",
+		ErrorUnreadable:   "Antwort des Servers konnte nicht gelesen werden",
+		ErrorTimeout:      "Zeitüberschreitung",
+		ErrorNoConnection: "Keine Verbindung zum Server",
+		ErrorUnknown:      "Unbekannter Fehler",
+	},
+	ENGLISH: {
+		DateTimeFormat: "15:04 01/02/06",
+		TimeFormat:     "15:04",
+
+		LoadingNews:       "Loading news...",
+		RetryingNews:      "retrying (%d/%d)…",
+		QuitHint:          "press q to quit",
+		LoadingNewsFailed: "Loading the news failed: %s",
+		OfflineSince:      "Offline – as of %s",
+
+		HeaderTitle:  "News",
+		TabNational:  "National",
+		TabRegional:  "Regional",
+		TabSearch:    "Search",
+		TabBookmarks: "Saved",
+
+		SearchPlaceholder: "Search ...",
+		NoResults:         "No results",
+		ResultCount:       "%d of %d results",
+		ResultCountAtMost: "%d of at most %d results",
+		LoadingMore:       "loading ...",
+		UnknownSortOrder:  "unknown sort order %q",
+		InvalidDate:       "invalid date %q",
+		InvalidDateRange:  "date range ends before it begins",
+
+		ModeArticle:       "Article",
+		ModeImage:         "Image",
+		ModeDetails:       "Details",
+		EmptyArticle:      "EMPTY",
+		UnparsableArticle: "Unable to parse and print article",
+		MediaPlaceholder:  "[%s: %s – press %d]",
+		MediaVideo:        "Video",
+		MediaAudio:        "Audio",
+		MediaGallery:      "Image gallery",
+		MediaSocial:       "Social media",
+		MediaWebview:      "Web content",
+		Links:             "Links",
+		LinkMode:          "Link: %s_",
+		MediaMode:         "Media: %s_",
+
+		DetailTitle:    "Title",
+		DetailSubtitle: "Subtitle",
+		DetailRegion:   "Region",
+		DetailRegions:  "Regions",
+		DetailRessort:  "Section",
+		DetailType:     "Type",
+		DetailBreaking: "Breaking news",
+		DetailTags:     "Tags",
+		DetailRelated:  "Related",
+		Yes:            "Yes",
+		No:             "No",
+
+		BreakingNews: "BREAKING NEWS",
+		OpenHint:     "open",
+
+		RegionPickerTitle: "Federal states",
+		RegionPickerHint:  "No selection shows all federal states, %s applies, %s discards",
+
+		ErrorStatus:       "Server responded with status %d (%s)",
+		ErrorContentType:  "Unexpected response of the server (%s)",
+		ErrorBodyTooLarge: "Response of the server is too large",
+		ErrorUnreadable:   "Response of the server could not be read",
+		ErrorTimeout:      "Timeout",
+		ErrorNoConnection: "No connection to the server",
+		ErrorUnknown:      "Unknown error",
+	},
+}
+
+var englishRessortNames = map[tagesschau.Ressort]string{
+	tagesschau.INLAND:       "Domestic",
+	tagesschau.AUSLAND:      "International",
+	tagesschau.WIRTSCHAFT:   "Economy",
+	tagesschau.SPORT:        "Sports",
+	tagesschau.VIDEO:        "Video",
+	tagesschau.INVESTIGATIV: "Investigative",
+	tagesschau.WISSEN:       "Science",
+}
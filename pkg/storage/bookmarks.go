@@ -0,0 +1,83 @@
+package storage
+
+import (
+	"os"
+	"sync"
+
+	"github.com/zMoooooritz/nachrichten/pkg/tagesschau"
+)
+
+// Bookmarks is a persistent list of articles saved for later, the full articles
+// are stored so that they remain readable after they dropped off the homepage.
+// The methods of a nil *Bookmarks are no-ops.
+type Bookmarks struct {
+	path     string
+	mutex    sync.RWMutex
+	articles []tagesschau.Article
+}
+
+// LoadBookmarks reads the bookmarks from the given path, a missing file results in no bookmarks
+func LoadBookmarks(path string) (*Bookmarks, error) {
+	bookmarks := &Bookmarks{
+		path:     path,
+		articles: []tagesschau.Article{},
+	}
+	err := LoadJSON(path, &bookmarks.articles)
+	if err != nil && !os.IsNotExist(err) {
+		return bookmarks, err
+	}
+	return bookmarks, nil
+}
+
+// Articles returns the bookmarked articles, the most recently added first
+func (b *Bookmarks) Articles() []tagesschau.Article {
+	if b == nil {
+		return nil
+	}
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+	return append([]tagesschau.Article{}, b.articles...)
+}
+
+func (b *Bookmarks) Contains(id string) bool {
+	if b == nil {
+		return false
+	}
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+	return b.indexOf(id) >= 0
+}
+
+// Toggle adds the article if it is not bookmarked yet and removes it otherwise,
+// true is returned if the article has been added
+func (b *Bookmarks) Toggle(article tagesschau.Article) bool {
+	if b == nil {
+		return false
+	}
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	if index := b.indexOf(article.ID); index >= 0 {
+		b.articles = append(b.articles[:index], b.articles[index+1:]...)
+		return false
+	}
+	b.articles = append([]tagesschau.Article{article}, b.articles...)
+	return true
+}
+
+func (b *Bookmarks) Save() error {
+	if b == nil {
+		return nil
+	}
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+	return SaveJSON(b.path, b.articles)
+}
+
+func (b *Bookmarks) indexOf(id string) int {
+	for i, article := range b.articles {
+		if article.ID == id {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,90 @@
+package storage
+
+import (
+	"os"
+	"sync"
+	"time"
+)
+
+// IDSet is a persistent set of article IDs, every ID remembers when it was added.
+// The methods of a nil *IDSet are no-ops.
+type IDSet struct {
+	path  string
+	mutex sync.RWMutex
+	ids   map[string]time.Time
+}
+
+// LoadIDSet reads the set from the given path, a missing file results in an empty set
+func LoadIDSet(path string) (*IDSet, error) {
+	set := &IDSet{
+		path: path,
+		ids:  make(map[string]time.Time),
+	}
+	err := LoadJSON(path, &set.ids)
+	if err != nil && !os.IsNotExist(err) {
+		return set, err
+	}
+	return set, nil
+}
+
+func (s *IDSet) Contains(id string) bool {
+	if s == nil {
+		return false
+	}
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	_, found := s.ids[id]
+	return found
+}
+
+func (s *IDSet) Add(id string) {
+	if s == nil {
+		return
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if _, found := s.ids[id]; !found {
+		s.ids[id] = time.Now()
+	}
+}
+
+func (s *IDSet) Remove(id string) {
+	if s == nil {
+		return
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	delete(s.ids, id)
+}
+
+func (s *IDSet) Len() int {
+	if s == nil {
+		return 0
+	}
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return len(s.ids)
+}
+
+// Prune forgets all IDs that have been added before the given duration
+func (s *IDSet) Prune(olderThan time.Duration) {
+	if s == nil {
+		return
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	for id, added := range s.ids {
+		if time.Since(added) > olderThan {
+			delete(s.ids, id)
+		}
+	}
+}
+
+func (s *IDSet) Save() error {
+	if s == nil {
+		return nil
+	}
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return SaveJSON(s.path, s.ids)
+}
@@ -0,0 +1,115 @@
+package storage
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/jpeg"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/zMoooooritz/nachrichten/pkg/tagesschau"
+	"github.com/zMoooooritz/nachrichten/pkg/util"
+)
+
+const (
+	snapshotFile string = "news.json"
+	imageDir     string = "images"
+	imageExt     string = ".jpg"
+	imageQuality int    = 90
+)
+
+var errNoSnapshot = errors.New("no snapshot available")
+
+// Snapshot persists the last successfully loaded news including the thumbnails
+// so that they can be read without a network connection.
+// The methods of a nil *Snapshot are no-ops.
+type Snapshot struct {
+	dir string
+}
+
+type snapshotData struct {
+	Timestamp time.Time       `json:"timestamp"`
+	News      tagesschau.News `json:"news"`
+}
+
+func NewSnapshot(dir string) *Snapshot {
+	return &Snapshot{dir: dir}
+}
+
+func (s *Snapshot) SaveNews(news tagesschau.News) error {
+	if s == nil {
+		return nil
+	}
+
+	err := SaveJSON(filepath.Join(s.dir, snapshotFile), snapshotData{Timestamp: time.Now(), News: news})
+	if err != nil {
+		return err
+	}
+	s.pruneImages(news)
+	return nil
+}
+
+// LoadNews returns the persisted news and the time they have been loaded
+func (s *Snapshot) LoadNews() (tagesschau.News, time.Time, error) {
+	var data snapshotData
+	if s == nil {
+		return data.News, data.Timestamp, errNoSnapshot
+	}
+
+	err := LoadJSON(filepath.Join(s.dir, snapshotFile), &data)
+	return data.News, data.Timestamp, err
+}
+
+func (s *Snapshot) SaveImage(id string, img image.Image) error {
+	if s == nil || id == "" {
+		return nil
+	}
+
+	var buf bytes.Buffer
+	err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: imageQuality})
+	if err != nil {
+		return err
+	}
+	return util.WriteFile(s.imagePath(id), buf.Bytes())
+}
+
+func (s *Snapshot) LoadImage(id string) (image.Image, error) {
+	if s == nil {
+		return nil, errNoSnapshot
+	}
+
+	file, err := os.Open(s.imagePath(id))
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	img, _, err := image.Decode(file)
+	return img, err
+}
+
+func (s *Snapshot) imagePath(id string) string {
+	return filepath.Join(s.dir, imageDir, filepath.Base(id)+imageExt)
+}
+
+// pruneImages removes the thumbnails of articles that are no longer part of the news
+func (s *Snapshot) pruneImages(news tagesschau.News) {
+	ids := make(map[string]bool)
+	for _, article := range append(news.NationalNews, news.RegionalNews...) {
+		ids[article.ID] = true
+	}
+
+	files, err := os.ReadDir(filepath.Join(s.dir, imageDir))
+	if err != nil {
+		return
+	}
+	for _, file := range files {
+		id := strings.TrimSuffix(file.Name(), imageExt)
+		if !ids[id] {
+			_ = os.Remove(filepath.Join(s.dir, imageDir, file.Name()))
+		}
+	}
+}
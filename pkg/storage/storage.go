@@ -0,0 +1,50 @@
+package storage
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"github.com/zMoooooritz/nachrichten/pkg/util"
+)
+
+const (
+	appDirName string = "nachrichten"
+)
+
+// CacheDir resolves to $XDG_CACHE_HOME/nachrichten or the platform equivalent
+func CacheDir() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, appDirName), nil
+}
+
+// DataDir resolves to $XDG_DATA_HOME/nachrichten and falls back to ~/.local/share/nachrichten
+func DataDir() (string, error) {
+	if dir := os.Getenv("XDG_DATA_HOME"); dir != "" {
+		return filepath.Join(dir, appDirName), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".local", "share", appDirName), nil
+}
+
+func LoadJSON(path string, v any) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+func SaveJSON(path string, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return util.WriteFile(path, data)
+}
@@ -1,23 +1,21 @@
 package tagesschau
 
 import (
-	"bytes"
-	"encoding/json"
 	"errors"
 	"time"
-
-	"github.com/PuerkitoBio/goquery"
-	"github.com/buger/jsonparser"
-	"github.com/zMoooooritz/nachrichten/pkg/http"
 )
 
 const (
-	baseUrl      string = "https://www.tagesschau.de/"
-	homepageAPI  string = baseUrl + "api2u/homepage/"
-	searchAPI    string = baseUrl + "api2u/search/"
-	shortNewsUrl string = baseUrl + "multimedia/sendung/tagesschau_in_100_sekunden"
+	DefaultBaseURL string = "https://www.tagesschau.de/"
+	homepageAPI    string = "api2u/homepage/"
+	newsAPI        string = "api2u/news/"
+	articleAPI     string = "api2u/"
+	searchAPI      string = "api2u/search/"
+	shortNewsUrl   string = "multimedia/sendung/tagesschau_in_100_sekunden"
 
 	emptyArticleToken string = "EMPTY_ARTICLE"
+
+	MaxSearchPageSize int = 30
 )
 
 type ImageSize int
@@ -48,6 +46,12 @@ type SearchResult struct {
 	ResultPage     int       `json:"resultPage"`
 	TotalItemCount int       `json:"totalItemCount"`
 	Articles       []Article `json:"searchResults"`
+	// FilteredOut is the number of articles of the page that did not match the filters of the query
+	FilteredOut int `json:"-"`
+}
+
+func (r SearchResult) HasMorePages() bool {
+	return (r.ResultPage+1)*r.PageSize < r.TotalItemCount
 }
 
 type Article struct {
@@ -107,13 +111,25 @@ type Tag struct {
 }
 
 type Content struct {
-	Value   string    `json:"value"`
-	Type    string    `json:"type"`
-	Related []Article `json:"related"`
+	Value     string       `json:"value"`
+	Type      string       `json:"type"`
+	Related   []Article    `json:"related"`
+	Quotation *Quotation   `json:"quotation"`
+	List      *List        `json:"list"`
+	Box       *Box         `json:"box"`
+	Audio     *Audio       `json:"audio"`
+	Video     *Video       `json:"video"`
+	Gallery   []ImageData  `json:"gallery"`
+	Image     *ImageData   `json:"image"`
+	Social    *SocialMedia `json:"social"`
+	Webview   *Webview     `json:"webview"`
+	HTMLEmbed *Webview     `json:"htmlEmbed"`
 }
 
 type ImageData struct {
 	Title         string        `json:"alttext"`
+	Caption       string        `json:"title"`
+	Copyright     string        `json:"copyright"`
 	Type          string        `json:"type"`
 	ImageVariants ImageVariants `json:"imageVariants"`
 }
@@ -139,6 +155,11 @@ type VideoVariants struct {
 	Big    string `json:"h264xl"`
 }
 
+func IsValidRegion(id int) bool {
+	_, ok := GERMAN_NAMES[RegionID(id)]
+	return ok && RegionID(id) != DE
+}
+
 func RegionIdToName(id int) (string, error) {
 	regionId := RegionID(id)
 	regionName, ok := GERMAN_NAMES[regionId]
@@ -148,53 +169,6 @@ func RegionIdToName(id int) (string, error) {
 	return "", errors.New("invalid regionId")
 }
 
-func LoadNews() (News, error) {
-	var news News
-	body, err := http.FetchURL(homepageAPI)
-	if err != nil {
-		return news, err
-	}
-
-	err = json.Unmarshal(body, &news)
-	if err != nil {
-		return news, err
-	}
-	news.NationalNews = deduplicateArticles(news.NationalNews)
-	news.RegionalNews = deduplicateArticles(news.RegionalNews)
-	return news, nil
-}
-
-func SearchArticles(searchTerm string) (SearchResult, error) {
-	var result SearchResult
-
-	body, err := http.FetchURL(searchAPI + "?searchText=" + searchTerm)
-	if err != nil {
-		return result, err
-	}
-
-	err = json.Unmarshal(body, &result)
-	if err != nil {
-		return result, err
-	}
-	result.Articles = deduplicateArticles(result.Articles)
-	result.Articles = removeUnreadableArticles(result.Articles)
-	return result, nil
-}
-
-func LoadArticle(url string) (*Article, error) {
-	body, err := http.FetchURL(url)
-	if err != nil {
-		return nil, err
-	}
-
-	var article Article
-	err = json.Unmarshal(body, &article)
-	if err != nil {
-		return nil, err
-	}
-	return &article, nil
-}
-
 func deduplicateArticles(articles []Article) []Article {
 	deduped := []Article{}
 	seen := make(map[string]bool)
@@ -219,6 +193,23 @@ func removeUnreadableArticles(articles []Article) []Article {
 	return cleaned
 }
 
+// rectImageWidths are the widths of the rectangular image sizes
+var rectImageWidths = map[ImageSize]int{
+	SMALL:  256,
+	MEDIUM: 640,
+	LARGE:  1920,
+}
+
+// ImageSizeForWidth returns the smallest rectangular image size that is at least the given number of pixels wide
+func ImageSizeForWidth(width int) ImageSize {
+	for _, size := range []ImageSize{SMALL, MEDIUM} {
+		if rectImageWidths[size] >= width {
+			return size
+		}
+	}
+	return LARGE
+}
+
 func GetImageURL(variants ImageVariants, imageSpec ImageSpec) string {
 	sizeMap := map[ImageSpec]string{
 		{SMALL, RECT}:    variants.RectSmall,
@@ -231,15 +222,52 @@ func GetImageURL(variants ImageVariants, imageSpec ImageSpec) string {
 	return sizeMap[imageSpec]
 }
 
+// GetNearestImageURL returns the url of the requested image variant, if it is missing the nearest available
+// variant is used, larger sizes are preferred over smaller ones and the requested ratio over the other one
+func GetNearestImageURL(variants ImageVariants, imageSpec ImageSpec) string {
+	ratios := []AspectRation{RECT, SQUARE}
+	if imageSpec.Ratio == SQUARE {
+		ratios = []AspectRation{SQUARE, RECT}
+	}
+
+	var sizes []ImageSize
+	for size := imageSpec.Size; size <= LARGE; size++ {
+		sizes = append(sizes, size)
+	}
+	for size := imageSpec.Size - 1; size >= SMALL; size-- {
+		sizes = append(sizes, size)
+	}
+
+	for _, ratio := range ratios {
+		for _, size := range sizes {
+			if url := GetImageURL(variants, ImageSpec{Size: size, Ratio: ratio}); url != "" {
+				return url
+			}
+		}
+	}
+	return ""
+}
+
 func (news *News) GetArticlesOfRegion(regionId RegionID) []Article {
-	allEntries := news.getCombinedArticles()
+	return news.GetArticlesOfRegions([]RegionID{regionId})
+}
+
+// GetArticlesOfRegions returns all articles that belong to at least one of the given regions
+func (news *News) GetArticlesOfRegions(regionIds []RegionID) []Article {
+	return FilterRegions(news.getCombinedArticles(), regionIds)
+}
+
+func FilterRegions(articles []Article, regionIds []RegionID) []Article {
 	entries := []Article{}
-	for _, e := range allEntries {
-		if contains(e.RegionIDs, regionId) {
-			entries = append(entries, e)
+	for _, e := range articles {
+		for _, regionId := range regionIds {
+			if contains(e.RegionIDs, regionId) {
+				entries = append(entries, e)
+				break
+			}
 		}
 	}
-	return entries
+	return deduplicateArticles(entries)
 }
 
 func (news *News) getCombinedArticles() []Article {
@@ -257,26 +285,3 @@ func contains(s []RegionID, e RegionID) bool {
 	}
 	return false
 }
-
-func GetShortNewsURL() (string, error) {
-	body, err := http.FetchURL(shortNewsUrl)
-	if err != nil {
-		return "", err
-	}
-
-	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(body))
-	if err != nil {
-		return "", err
-	}
-
-	data, exists := doc.Find("div.teaser__media").Find("div.v-instance").Attr("data-v")
-	if !exists {
-		return "", errors.New("Unable to parse HTML to find URL")
-	}
-
-	url, err := jsonparser.GetString([]byte(data), "mc", "streams", "[0]", "media", "[4]", "url")
-	if err != nil {
-		return "", err
-	}
-	return url, nil
-}
@@ -0,0 +1,226 @@
+package tagesschau
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"image"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/buger/jsonparser"
+	"github.com/zMoooooritz/nachrichten/pkg/http"
+)
+
+type Client struct {
+	baseURL string
+	http    *http.Client
+}
+
+type Option func(*Client)
+
+// WithUserAgent overrides the User-Agent header sent with every request.
+func WithUserAgent(userAgent string) Option {
+	return func(c *Client) {
+		c.http.SetUserAgent(userAgent)
+	}
+}
+
+// WithRetryPolicy configures how transient failures are retried.
+func WithRetryPolicy(policy http.RetryPolicy) Option {
+	return func(c *Client) {
+		c.http.SetRetryPolicy(policy)
+	}
+}
+
+// WithCache stores responses in the given cache and revalidates them once stale.
+func WithCache(cache *http.Cache) Option {
+	return func(c *Client) {
+		c.http.SetCache(cache)
+	}
+}
+
+func NewClient(baseURL string, doer http.Doer, opts ...Option) *Client {
+	if !strings.HasSuffix(baseURL, "/") {
+		baseURL += "/"
+	}
+
+	c := &Client{
+		baseURL: baseURL,
+		http:    http.NewClient(doer),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+func DefaultClient() *Client {
+	return NewClient(DefaultBaseURL, http.DefaultDoer())
+}
+
+func (c *Client) LoadNews(ctx context.Context) (News, error) {
+	var news News
+	body, err := c.http.FetchURL(ctx, c.baseURL+homepageAPI, http.ContentTypeJSON)
+	if err != nil {
+		return news, fmt.Errorf("loading news: %w", err)
+	}
+
+	err = json.Unmarshal(body, &news)
+	if err != nil {
+		return news, fmt.Errorf("decoding news: %w", err)
+	}
+	news.NationalNews = deduplicateArticles(news.NationalNews)
+	news.RegionalNews = deduplicateArticles(news.RegionalNews)
+	return news, nil
+}
+
+// LoadRessort loads the current articles of the given ressort
+func (c *Client) LoadRessort(ctx context.Context, ressort Ressort) ([]Article, error) {
+	var news News
+	body, err := c.http.FetchURL(ctx, c.baseURL+newsAPI+"?ressort="+url.QueryEscape(string(ressort)), http.ContentTypeJSON)
+	if err != nil {
+		return nil, fmt.Errorf("loading ressort %s: %w", ressort, err)
+	}
+
+	err = json.Unmarshal(body, &news)
+	if err != nil {
+		return nil, fmt.Errorf("decoding ressort %s: %w", ressort, err)
+	}
+	return deduplicateArticles(news.NationalNews), nil
+}
+
+// LoadRegionalNews loads the current articles of the given regions
+func (c *Client) LoadRegionalNews(ctx context.Context, regions []RegionID) ([]Article, error) {
+	var ids []string
+	for _, region := range regions {
+		ids = append(ids, strconv.Itoa(int(region)))
+	}
+
+	var news News
+	body, err := c.http.FetchURL(ctx, c.baseURL+newsAPI+"?regions="+url.QueryEscape(strings.Join(ids, ",")), http.ContentTypeJSON)
+	if err != nil {
+		return nil, fmt.Errorf("loading regional news: %w", err)
+	}
+
+	err = json.Unmarshal(body, &news)
+	if err != nil {
+		return nil, fmt.Errorf("decoding regional news: %w", err)
+	}
+	return FilterRegions(news.getCombinedArticles(), regions), nil
+}
+
+// SearchArticles loads the given page of the search results, the first page has the index 0.
+// Not all filters are guaranteed to be honoured by the API, therefore they are applied to the results as well.
+// The sort order is only applied within the page, the combined pages have to be sorted with SearchQuery.SortArticles.
+func (c *Client) SearchArticles(ctx context.Context, query SearchQuery, page, pageSize int) (SearchResult, error) {
+	var result SearchResult
+
+	url := c.baseURL + searchAPI + "?" + query.values(page, pageSize).Encode()
+	body, err := c.http.FetchURL(ctx, url, http.ContentTypeJSON)
+	if err != nil {
+		return result, fmt.Errorf("searching articles: %w", err)
+	}
+
+	err = json.Unmarshal(body, &result)
+	if err != nil {
+		return result, fmt.Errorf("decoding search result: %w", err)
+	}
+	// not every response carries the paging information
+	result.ResultPage = page
+	if result.PageSize == 0 {
+		result.PageSize = pageSize
+	}
+	result.Articles = deduplicateArticles(result.Articles)
+	result.Articles = removeUnreadableArticles(result.Articles)
+	filtered := query.filter(result.Articles)
+	result.FilteredOut = len(result.Articles) - len(filtered)
+	result.Articles = filtered
+	query.SortArticles(result.Articles)
+	return result, nil
+}
+
+func (c *Client) LoadArticle(ctx context.Context, url string) (*Article, error) {
+	body, err := c.http.FetchURL(ctx, c.resolveURL(url), http.ContentTypeJSON)
+	if err != nil {
+		return nil, fmt.Errorf("loading article: %w", err)
+	}
+
+	var article Article
+	err = json.Unmarshal(body, &article)
+	if err != nil {
+		return nil, fmt.Errorf("decoding article: %w", err)
+	}
+	return &article, nil
+}
+
+// ArticleAPIURL returns the API URL of a link to an article on tagesschau.de,
+// false is returned if the link does not point to an article
+func (c *Client) ArticleAPIURL(link string) (string, bool) {
+	u, err := url.Parse(link)
+	if err != nil || !strings.HasSuffix(u.Path, ".html") {
+		return "", false
+	}
+	if u.Host != "tagesschau.de" && u.Host != "www.tagesschau.de" {
+		return "", false
+	}
+	path := strings.TrimPrefix(strings.TrimSuffix(u.Path, ".html"), "/")
+	return c.baseURL + articleAPI + path + ".json", true
+}
+
+// resolveURL points the absolute tagesschau.de URLs contained in the responses
+// to the base URL of the client, unless the client talks to tagesschau.de itself
+func (c *Client) resolveURL(link string) string {
+	base, err := url.Parse(c.baseURL)
+	if err != nil || isTagesschauHost(base.Host) {
+		return link
+	}
+	u, err := url.Parse(link)
+	if err != nil || !isTagesschauHost(u.Host) {
+		return link
+	}
+	u.Scheme = base.Scheme
+	u.Host = base.Host
+	u.Path = strings.TrimSuffix(base.Path, "/") + u.Path
+	u.RawPath = ""
+	return u.String()
+}
+
+func isTagesschauHost(host string) bool {
+	return host == "tagesschau.de" || strings.HasSuffix(host, ".tagesschau.de")
+}
+
+func (c *Client) LoadImage(ctx context.Context, url string) (image.Image, error) {
+	img, err := c.http.LoadImage(ctx, c.resolveURL(url))
+	if err != nil {
+		return nil, fmt.Errorf("loading image: %w", err)
+	}
+	return img, nil
+}
+
+func (c *Client) GetShortNewsURL(ctx context.Context) (string, error) {
+	body, err := c.http.FetchURL(ctx, c.baseURL+shortNewsUrl, http.ContentTypeHTML)
+	if err != nil {
+		return "", fmt.Errorf("loading short news: %w", err)
+	}
+
+	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+
+	data, exists := doc.Find("div.teaser__media").Find("div.v-instance").Attr("data-v")
+	if !exists {
+		return "", errors.New("Unable to parse HTML to find URL")
+	}
+
+	url, err := jsonparser.GetString([]byte(data), "mc", "streams", "[0]", "media", "[4]", "url")
+	if err != nil {
+		return "", err
+	}
+	return url, nil
+}
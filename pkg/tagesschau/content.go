@@ -0,0 +1,143 @@
+package tagesschau
+
+type Quotation struct {
+	Text        string `json:"text"`
+	Attribution string `json:"attribution"`
+}
+
+type List struct {
+	Title string     `json:"title"`
+	Items []ListItem `json:"items"`
+}
+
+type ListItem struct {
+	Text string `json:"text"`
+	URL  string `json:"url"`
+}
+
+type Box struct {
+	Title    string `json:"title"`
+	Subtitle string `json:"subtitle"`
+	Text     string `json:"text"`
+	Source   string `json:"source"`
+	Link     string `json:"link"`
+}
+
+type Audio struct {
+	Title  string `json:"title"`
+	Stream string `json:"stream"`
+}
+
+type SocialMedia struct {
+	Type      string `json:"type"`
+	Account   string `json:"account"`
+	Title     string `json:"title"`
+	ShortText string `json:"shorttext"`
+	URL       string `json:"url"`
+}
+
+type Webview struct {
+	Title   string `json:"title"`
+	Service string `json:"service"`
+	URL     string `json:"url"`
+}
+
+type MediaType int
+
+const (
+	MT_VIDEO MediaType = iota
+	MT_AUDIO
+	MT_GALLERY
+	MT_SOCIAL
+	MT_WEBVIEW
+)
+
+// Media is a content block that can not be shown as text but opened in an external application
+type Media struct {
+	Type   MediaType
+	Title  string
+	URL    string
+	Images []ImageData
+}
+
+// Media returns the media of the content block, false is returned for text blocks
+func (c Content) Media() (Media, bool) {
+	switch c.Type {
+	case "video":
+		if c.Video == nil {
+			break
+		}
+		return Media{Type: MT_VIDEO, Title: c.Video.Title, URL: c.Video.VideoVariants.Big}, true
+	case "audio":
+		if c.Audio == nil {
+			break
+		}
+		return Media{Type: MT_AUDIO, Title: c.Audio.Title, URL: c.Audio.Stream}, true
+	case "image_gallery":
+		if len(c.Gallery) == 0 {
+			break
+		}
+		return Media{Type: MT_GALLERY, Title: c.Gallery[0].Title, URL: GetImageURL(c.Gallery[0].ImageVariants, ImageSpec{LARGE, RECT}), Images: c.Gallery}, true
+	case "socialmedia":
+		if c.Social == nil {
+			break
+		}
+		title := c.Social.Title
+		if title == "" {
+			title = c.Social.Account
+		}
+		return Media{Type: MT_SOCIAL, Title: title, URL: c.Social.URL}, true
+	case "webview", "htmlEmbed":
+		webview := c.Webview
+		if c.Type == "htmlEmbed" {
+			webview = c.HTMLEmbed
+		}
+		if webview == nil {
+			break
+		}
+		title := webview.Title
+		if title == "" {
+			title = webview.Service
+		}
+		return Media{Type: MT_WEBVIEW, Title: title, URL: webview.URL}, true
+	}
+	return Media{}, false
+}
+
+// GetMedia returns all media of the article in the order of their appearance
+func (n Article) GetMedia() []Media {
+	media := []Media{}
+	for _, content := range n.Content {
+		if m, ok := content.Media(); ok {
+			media = append(media, m)
+		}
+	}
+	return media
+}
+
+// GetImages returns the teaser image followed by all images of the content in the order of their appearance
+func (n Article) GetImages() []ImageData {
+	images := []ImageData{}
+	known := make(map[string]bool)
+	add := func(image ImageData) {
+		url := GetImageURL(image.ImageVariants, ImageSpec{SMALL, RECT})
+		if url == "" || known[url] {
+			return
+		}
+		known[url] = true
+		images = append(images, image)
+	}
+
+	add(n.ImageData)
+	for _, content := range n.Content {
+		switch {
+		case content.Type == "image" && content.Image != nil:
+			add(*content.Image)
+		case content.Type == "image_gallery":
+			for _, image := range content.Gallery {
+				add(image)
+			}
+		}
+	}
+	return images
+}
@@ -1,6 +1,8 @@
 package tagesschau
 
 import (
+	"fmt"
+	"html"
 	"regexp"
 	"strings"
 	"unicode"
@@ -8,12 +10,56 @@ import (
 
 const (
 	timeRegex = `\b\d{1,2}:\d{2}\b`
+	linkRegex = `(?s)<a\s[^>]*?href="([^"]*)"[^>]*>(.*?)</a>`
 )
 
-func ContentToParagraphs(content []Content) []string {
+var linkPattern = regexp.MustCompile(linkRegex)
+
+// Link is a hyperlink of the article text, it is referenced by its position in the list of links
+type Link struct {
+	Text string
+	URL  string
+}
+
+// linkCollector replaces hyperlinks by numbered references and remembers their targets
+type linkCollector struct {
+	links   []Link
+	numbers map[string]int
+}
+
+func (l *linkCollector) replace(text string) string {
+	return linkPattern.ReplaceAllStringFunc(text, func(match string) string {
+		groups := linkPattern.FindStringSubmatch(match)
+		url, linkText := html.UnescapeString(groups[1]), groups[2]
+		if strings.HasPrefix(url, "/") {
+			url = strings.TrimSuffix(DefaultBaseURL, "/") + url
+		}
+		if url == "" || strings.HasPrefix(url, "#") {
+			return linkText
+		}
+
+		number, found := l.numbers[url]
+		if !found {
+			l.links = append(l.links, Link{Text: linkText, URL: url})
+			number = len(l.links)
+			l.numbers[url] = number
+		}
+		return fmt.Sprintf("%s [%d]", linkText, number)
+	})
+}
+
+// PlaceholderFunc returns the text shown in place of the media with the given index
+type PlaceholderFunc func(index int, media Media) string
+
+// ContentToParagraphs converts the content of an article to HTML paragraphs,
+// media are replaced by the text returned by the placeholder function
+// and hyperlinks by numbered references to the returned links
+func ContentToParagraphs(content []Content, placeholder PlaceholderFunc) ([]string, []Link) {
+	links := &linkCollector{numbers: make(map[string]int)}
 	prevType := "text"
 	prevSection := false
 	paragraph := ""
+	mediaIndex := 0
 	var paragraphs []string
 	for i, c := range content {
 		switch c.Type {
@@ -30,18 +76,7 @@ func ContentToParagraphs(content []Content) []string {
 				continue
 			}
 
-			// remove hyperlinks from text
-			for {
-				startIdx := strings.Index(text, "<a ")
-				if startIdx == -1 {
-					break
-				}
-				endIndex := strings.Index(text, "\">")
-				if endIndex == -1 {
-					break
-				}
-				text = text[:startIdx+2] + text[endIndex+1:]
-			}
+			text = links.replace(text)
 
 			sec := isSection(text)
 			if (prevType != c.Type || sec || prevSection) && paragraph != "" || i == len(content)-1 {
@@ -50,11 +85,68 @@ func ContentToParagraphs(content []Content) []string {
 			}
 			paragraph += text + " "
 			prevSection = sec
+		default:
+			block := ""
+			if media, ok := c.Media(); ok {
+				mediaIndex++
+				block = "<p><em>" + html.EscapeString(placeholder(mediaIndex, media)) + "</em></p>"
+			} else {
+				block = blockToHTML(c, links)
+			}
+			if block == "" {
+				prevType = c.Type
+				continue
+			}
+
+			if paragraph != "" {
+				paragraphs = append(paragraphs, clean(paragraph))
+				paragraph = ""
+			}
+			paragraphs = append(paragraphs, clean(block))
+			prevSection = false
 		}
 		prevType = c.Type
 	}
 	paragraphs = append(paragraphs, clean(formatLastLine(paragraph)))
-	return paragraphs
+	return paragraphs, links.links
+}
+
+// blockToHTML converts quotations, lists and boxes, other blocks are dropped
+func blockToHTML(c Content, links *linkCollector) string {
+	switch {
+	case c.Type == "quotation" && c.Quotation != nil:
+		quote := "<blockquote><p>" + links.replace(c.Quotation.Text) + "</p>"
+		if c.Quotation.Attribution != "" {
+			quote += "<p>– " + c.Quotation.Attribution + "</p>"
+		}
+		return quote + "</blockquote>"
+	case c.Type == "list" && c.List != nil:
+		list := ""
+		if c.List.Title != "" {
+			list += "<p><strong>" + c.List.Title + "</strong></p>"
+		}
+		list += "<ul>"
+		for _, item := range c.List.Items {
+			list += "<li>" + links.replace(item.Text) + "</li>"
+		}
+		return list + "</ul>"
+	case c.Type == "box" && c.Box != nil:
+		box := "<blockquote>"
+		if c.Box.Title != "" {
+			box += "<p><strong>" + c.Box.Title + "</strong></p>"
+		}
+		if c.Box.Subtitle != "" {
+			box += "<p><em>" + c.Box.Subtitle + "</em></p>"
+		}
+		if c.Box.Text != "" {
+			box += "<p>" + links.replace(c.Box.Text) + "</p>"
+		}
+		if c.Box.Source != "" {
+			box += "<p><em>" + c.Box.Source + "</em></p>"
+		}
+		return box + "</blockquote>"
+	}
+	return ""
 }
 
 func clean(s string) string {
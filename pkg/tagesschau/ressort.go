@@ -0,0 +1,28 @@
+package tagesschau
+
+type Ressort string
+
+const (
+	INLAND       Ressort = "inland"
+	AUSLAND      Ressort = "ausland"
+	WIRTSCHAFT   Ressort = "wirtschaft"
+	SPORT        Ressort = "sport"
+	VIDEO        Ressort = "video"
+	INVESTIGATIV Ressort = "investigativ"
+	WISSEN       Ressort = "wissen"
+)
+
+var RESSORT_NAMES = map[Ressort]string{
+	INLAND:       "Inland",
+	AUSLAND:      "Ausland",
+	WIRTSCHAFT:   "Wirtschaft",
+	SPORT:        "Sport",
+	VIDEO:        "Video",
+	INVESTIGATIV: "Investigativ",
+	WISSEN:       "Wissen",
+}
+
+func IsValidRessort(ressort string) bool {
+	_, ok := RESSORT_NAMES[Ressort(ressort)]
+	return ok
+}
@@ -0,0 +1,186 @@
+package tagesschau
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type SortOrder int
+
+const (
+	SortRelevance SortOrder = iota
+	SortNewest
+	SortOldest
+)
+
+var sortOrderNames = map[string]SortOrder{
+	"relevanz":  SortRelevance,
+	"relevance": SortRelevance,
+	"neueste":   SortNewest,
+	"newest":    SortNewest,
+	"älteste":   SortOldest,
+	"oldest":    SortOldest,
+}
+
+var dateFormats = []string{"2006-01-02", "02.01.2006", "2.1.2006"}
+
+type QueryErrorKind int
+
+const (
+	UnknownSortOrder QueryErrorKind = iota
+	InvalidDate
+	InvalidDateRange
+)
+
+// QueryError describes why a search input could not be parsed
+type QueryError struct {
+	Kind  QueryErrorKind
+	Value string
+}
+
+func (e *QueryError) Error() string {
+	switch e.Kind {
+	case UnknownSortOrder:
+		return fmt.Sprintf("unknown sort order %q", e.Value)
+	case InvalidDate:
+		return fmt.Sprintf("invalid date %q", e.Value)
+	default:
+		return "date range ends before it starts"
+	}
+}
+
+// SearchQuery describes a search, all filters besides the text are optional
+type SearchQuery struct {
+	Text    string
+	Ressort string
+	Type    string
+	From    time.Time
+	To      time.Time
+	Sort    SortOrder
+}
+
+// ParseSearchQuery parses the search input, filters are given as key:value pairs
+// e.g. "klima ressort:wirtschaft seit:2024-01-01 bis:2024-06-30 typ:story sortierung:neueste",
+// words that do not form a known filter are part of the search text
+func ParseSearchQuery(input string) (SearchQuery, error) {
+	var query SearchQuery
+	var words []string
+
+	for _, word := range strings.Fields(input) {
+		key, value, found := strings.Cut(word, ":")
+		if !found || value == "" {
+			words = append(words, word)
+			continue
+		}
+
+		var err error
+		switch strings.ToLower(key) {
+		case "ressort":
+			query.Ressort = strings.ToLower(value)
+		case "typ", "type":
+			query.Type = strings.ToLower(value)
+		case "seit", "von", "from", "since":
+			query.From, err = parseDate(value)
+		case "bis", "to", "until":
+			query.To, err = parseDate(value)
+		case "sortierung", "sort":
+			sort, ok := sortOrderNames[strings.ToLower(value)]
+			if !ok {
+				err = &QueryError{Kind: UnknownSortOrder, Value: value}
+			}
+			query.Sort = sort
+		default:
+			words = append(words, word)
+		}
+		if err != nil {
+			return query, err
+		}
+	}
+
+	query.Text = strings.Join(words, " ")
+	if !query.From.IsZero() && !query.To.IsZero() && query.To.Before(query.From) {
+		return query, &QueryError{Kind: InvalidDateRange}
+	}
+	return query, nil
+}
+
+func parseDate(value string) (time.Time, error) {
+	for _, format := range dateFormats {
+		date, err := time.ParseInLocation(format, value, time.Local)
+		if err == nil {
+			return date, nil
+		}
+	}
+	return time.Time{}, &QueryError{Kind: InvalidDate, Value: value}
+}
+
+// values encodes the query as URL parameters
+func (q SearchQuery) values(page, pageSize int) url.Values {
+	values := url.Values{}
+	values.Set("searchText", q.Text)
+	values.Set("resultPage", strconv.Itoa(page))
+	values.Set("pageSize", strconv.Itoa(pageSize))
+	if q.Ressort != "" {
+		values.Set("ressort", q.Ressort)
+	}
+	if q.Type != "" {
+		values.Set("type", q.Type)
+	}
+	if !q.From.IsZero() {
+		values.Set("dateFrom", q.From.Format("2006-01-02"))
+	}
+	if !q.To.IsZero() {
+		values.Set("dateTo", q.To.Format("2006-01-02"))
+	}
+	switch q.Sort {
+	case SortNewest:
+		values.Set("sortBy", "date")
+	case SortOldest:
+		values.Set("sortBy", "date")
+		values.Set("sortOrder", "asc")
+	}
+	return values
+}
+
+// Matches checks the filters of the query against the article
+func (q SearchQuery) Matches(article Article) bool {
+	if q.Ressort != "" && !strings.EqualFold(article.Ressort, q.Ressort) {
+		return false
+	}
+	if q.Type != "" && !strings.EqualFold(article.Type, q.Type) {
+		return false
+	}
+	if !q.From.IsZero() && article.Date.Before(q.From) {
+		return false
+	}
+	// the end of the range is inclusive
+	if !q.To.IsZero() && !article.Date.Before(q.To.AddDate(0, 0, 1)) {
+		return false
+	}
+	return true
+}
+
+// filter removes the articles that do not match the filters of the query
+func (q SearchQuery) filter(articles []Article) []Article {
+	filtered := []Article{}
+	for _, article := range articles {
+		if q.Matches(article) {
+			filtered = append(filtered, article)
+		}
+	}
+	return filtered
+}
+
+// SortArticles sorts the articles according to the query, the order of the API is kept when sorting by relevance
+func (q SearchQuery) SortArticles(articles []Article) {
+	switch q.Sort {
+	case SortNewest:
+		slices.SortStableFunc(articles, func(a, b Article) int { return b.Date.Compare(a.Date) })
+	case SortOldest:
+		slices.SortStableFunc(articles, func(a, b Article) int { return a.Date.Compare(b.Date) })
+	}
+}
@@ -4,7 +4,7 @@ import (
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/list"
 	tea "github.com/charmbracelet/bubbletea"
-	"github.com/zMoooooritz/nachrichten/pkg/config"
+	"github.com/zMoooooritz/nachrichten/pkg/i18n"
 	"github.com/zMoooooritz/nachrichten/pkg/tagesschau"
 )
 
@@ -14,11 +14,18 @@ const (
 	ST_NATIONAL SelectorType = iota
 	ST_REGIONAL
 	ST_SEARCH
+	ST_RESSORT
+	ST_BOOKMARKS
 )
 
 type Selector interface {
 	PushSelectedArticle() tea.Cmd
+	SelectArticle(string) bool
+	SelectNextUnread() bool
+	MarkAllRead()
+	UnreadCount() int
 	SelectorType() SelectorType
+	Name() string
 	SetVisible(bool)
 	IsVisible() bool
 	SetActive(bool)
@@ -52,12 +59,13 @@ func NewSelector(selectorType SelectorType, shared *SharedState, isActive bool)
 		isActive:      isActive,
 		isFocused:     isActive,
 		isVisible:     true,
-		list:          initList(shared.style, listKeymap),
+		list:          initList(shared, listKeymap),
 		selectedIndex: 0,
 	}
 }
-func initList(s config.Style, km list.KeyMap) list.Model {
-	lst := list.New([]list.Item{}, NewNewsDelegate(s), 0, 0)
+
+func initList(shared *SharedState, km list.KeyMap) list.Model {
+	lst := list.New([]list.Item{}, NewNewsDelegate(shared), 0, 0)
 	lst.SetFilteringEnabled(false)
 	lst.SetShowTitle(false)
 	lst.SetShowStatusBar(false)
@@ -76,6 +84,34 @@ func (s *BaseSelector) rebuildList() {
 	s.selectedIndex = 0
 }
 
+// replaceArticles swaps the shown articles while keeping the selected article selected
+func (s *BaseSelector) replaceArticles(articles []tagesschau.Article) {
+	if len(articles) == 0 {
+		return
+	}
+
+	selectedID := ""
+	if s.selectedIndex < len(s.articles) {
+		selectedID = s.getSelectedArticle().ID
+	}
+	index := min(s.selectedIndex, len(articles)-1)
+	for i, article := range articles {
+		if article.ID == selectedID {
+			index = i
+			break
+		}
+	}
+
+	s.articles = articles
+	var items []list.Item
+	for _, article := range s.articles {
+		items = append(items, article)
+	}
+	s.list.SetItems(items)
+	s.list.Select(index)
+	s.selectedIndex = index
+}
+
 func (s *BaseSelector) getSelectedArticle() tagesschau.Article {
 	return s.articles[s.selectedIndex]
 }
@@ -86,10 +122,67 @@ func (s BaseSelector) PushSelectedArticle() tea.Cmd {
 	}
 }
 
+// SelectArticle selects the article with the given ID if it is part of the selector
+func (s *BaseSelector) SelectArticle(id string) bool {
+	for i, article := range s.articles {
+		if article.ID == id {
+			s.list.Select(i)
+			s.selectedIndex = i
+			return true
+		}
+	}
+	return false
+}
+
+// SelectNextUnread selects the next unread article after the selected one, the search wraps around
+func (s *BaseSelector) SelectNextUnread() bool {
+	for offset := 1; offset < len(s.articles); offset++ {
+		i := (s.selectedIndex + offset) % len(s.articles)
+		if !s.articles[i].IsEmptyArticle() && !s.shared.read.Contains(s.articles[i].ID) {
+			s.list.Select(i)
+			s.selectedIndex = i
+			return true
+		}
+	}
+	return false
+}
+
+func (s *BaseSelector) MarkAllRead() {
+	for _, article := range s.articles {
+		if !article.IsEmptyArticle() {
+			s.shared.read.Add(article.ID)
+		}
+	}
+}
+
+func (s BaseSelector) UnreadCount() int {
+	count := 0
+	for _, article := range s.articles {
+		if !article.IsEmptyArticle() && !s.shared.read.Contains(article.ID) {
+			count++
+		}
+	}
+	return count
+}
+
 func (s BaseSelector) SelectorType() SelectorType {
 	return s.selectorType
 }
 
+func (s BaseSelector) Name() string {
+	switch s.selectorType {
+	case ST_NATIONAL:
+		return s.shared.catalog.T(i18n.TabNational)
+	case ST_REGIONAL:
+		return s.shared.catalog.T(i18n.TabRegional)
+	case ST_SEARCH:
+		return s.shared.catalog.T(i18n.TabSearch)
+	case ST_BOOKMARKS:
+		return s.shared.catalog.T(i18n.TabBookmarks)
+	}
+	return ""
+}
+
 func (s *BaseSelector) SetVisible(isVisible bool) {
 	s.isVisible = isVisible
 }
@@ -136,11 +229,11 @@ func (s BaseSelector) Update(msg tea.Msg) (BaseSelector, tea.Cmd) {
 
 		switch {
 		case key.Matches(msg, s.shared.keymap.right):
-			if s.isActive {
+			if s.isActive && !s.shared.panning {
 				s.isFocused = false
 			}
 		case key.Matches(msg, s.shared.keymap.left):
-			if s.isActive {
+			if s.isActive && !s.shared.panning {
 				s.isFocused = true
 			}
 		case key.Matches(msg, s.shared.keymap.full):
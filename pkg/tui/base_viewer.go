@@ -10,13 +10,10 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/zMoooooritz/nachrichten/pkg/config"
+	"github.com/zMoooooritz/nachrichten/pkg/i18n"
 	"github.com/zMoooooritz/nachrichten/pkg/tagesschau"
 )
 
-const (
-	emptyArticleHeader string = "LEER"
-)
-
 type ViewerType int
 
 const (
@@ -29,6 +26,8 @@ type Viewer interface {
 	SetArticle(tagesschau.Article)
 	SetHeaderData(tagesschau.Article)
 	ViewerType() ViewerType
+	ScrollOffset() int
+	SetScrollOffset(int)
 	GotoTop()
 	GotoBottom()
 	SetActive(bool)
@@ -52,6 +51,7 @@ type BaseViewer struct {
 	title        string
 	date         string
 	modeName     string
+	status       string
 	viewport     viewport.Model
 }
 
@@ -82,6 +82,14 @@ func (v *BaseViewer) GotoTop() {
 	v.viewport.GotoTop()
 }
 
+func (v BaseViewer) ScrollOffset() int {
+	return v.viewport.YOffset
+}
+
+func (v *BaseViewer) SetScrollOffset(offset int) {
+	v.viewport.SetYOffset(offset)
+}
+
 func (v *BaseViewer) GotoBottom() {
 	v.viewport.GotoBottom()
 }
@@ -119,7 +127,7 @@ func (v *BaseViewer) SetDims(w, h int) {
 func (v *BaseViewer) SetHeaderData(article tagesschau.Article) {
 	date := time.Now()
 	if article.IsEmptyArticle() {
-		v.title = emptyArticleHeader
+		v.title = v.shared.catalog.T(i18n.EmptyArticle)
 	} else if article.IsRegionalArticle() {
 		v.title = article.Desc
 		date = article.Date
@@ -131,7 +139,7 @@ func (v *BaseViewer) SetHeaderData(article tagesschau.Article) {
 		}
 		date = article.Date
 	}
-	v.date = date.Format(germanDateFormat)
+	v.date = date.Format(v.shared.catalog.T(i18n.DateTimeFormat))
 }
 
 func (v BaseViewer) Init() tea.Cmd {
@@ -147,11 +155,11 @@ func (v BaseViewer) Update(msg tea.Msg) (BaseViewer, tea.Cmd) {
 
 		switch {
 		case key.Matches(msg, v.shared.keymap.right):
-			if v.isActive {
+			if v.isActive && !v.shared.panning {
 				v.isFocused = true
 			}
 		case key.Matches(msg, v.shared.keymap.left):
-			if v.isActive {
+			if v.isActive && !v.shared.panning {
 				v.isFocused = false
 			}
 		case key.Matches(msg, v.shared.keymap.start):
@@ -214,7 +222,11 @@ func (v BaseViewer) footerView() string {
 	if v.modeName != "" {
 		mode = modeStyle.Render(v.modeName)
 	}
-	info := infoStyle.Render(fmt.Sprintf("%3.f%%", v.viewport.ScrollPercent()*100))
+	info := fmt.Sprintf("%3.f%%", v.viewport.ScrollPercent()*100)
+	if v.status != "" {
+		info = v.status + " " + info
+	}
+	info = infoStyle.Render(info)
 	line := lineStyle.Render(strings.Repeat(fillCharacter, max(0, v.viewport.Width-lipgloss.Width(mode)-lipgloss.Width(info))))
 
 	return lipgloss.JoinHorizontal(lipgloss.Center, mode, line, info)
@@ -0,0 +1,72 @@
+package tui
+
+import (
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/zMoooooritz/nachrichten/pkg/tagesschau"
+)
+
+type BookmarkSelector struct {
+	BaseSelector
+}
+
+func NewBookmarkSelector(selector BaseSelector) *BookmarkSelector {
+	s := &BookmarkSelector{
+		BaseSelector: selector,
+	}
+	s.showBookmarks()
+	return s
+}
+
+func (s BookmarkSelector) Init() tea.Cmd {
+	return nil
+}
+
+func (s *BookmarkSelector) Update(msg tea.Msg) (Selector, tea.Cmd) {
+	var (
+		cmd  tea.Cmd
+		cmds []tea.Cmd
+	)
+
+	switch msg := msg.(type) {
+	case UpdatedBookmarks:
+		s.showBookmarks()
+		if s.isActive {
+			cmds = append(cmds, s.PushSelectedArticle())
+		}
+	case tea.KeyMsg:
+		if s.isFocused && s.isVisible {
+			s.list, cmd = s.list.Update(msg)
+			cmds = append(cmds, cmd)
+		}
+	}
+
+	bs, cmd := s.BaseSelector.Update(msg)
+	cmds = append(cmds, cmd)
+	s.BaseSelector = bs
+	return s, tea.Batch(cmds...)
+}
+
+// showBookmarks shows the current bookmarks while keeping the selection
+func (s *BookmarkSelector) showBookmarks() {
+	articles := s.shared.bookmarks.Articles()
+	if len(articles) == 0 {
+		s.articles = []tagesschau.Article{tagesschau.EMPTY_ARTICLE()}
+		s.list.SetItems([]list.Item{})
+		s.list.Select(0)
+		s.selectedIndex = 0
+		return
+	}
+	if len(s.articles) == 1 && s.articles[0].IsEmptyArticle() {
+		s.articles = articles
+		s.rebuildList()
+		return
+	}
+	s.replaceArticles(articles)
+}
+
+func (s BookmarkSelector) View() string {
+	s.list.SetSize(s.width, s.height)
+
+	return s.list.View()
+}
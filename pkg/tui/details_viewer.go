@@ -1,23 +1,28 @@
 package tui
 
 import (
+	"context"
 	"fmt"
 	"strconv"
 	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/zMoooooritz/nachrichten/pkg/i18n"
 	"github.com/zMoooooritz/nachrichten/pkg/tagesschau"
+	"github.com/zMoooooritz/nachrichten/pkg/util"
 	"golang.org/x/text/cases"
 	"golang.org/x/text/language"
 )
 
 type Details struct {
 	BaseViewer
+	cancelLoad context.CancelFunc
+	loadingFor string
 }
 
 func NewDetails(viewer BaseViewer) *Details {
-	viewer.modeName = "Details"
+	viewer.modeName = viewer.shared.catalog.T(i18n.ModeDetails)
 	return &Details{
 		BaseViewer: viewer,
 	}
@@ -30,8 +35,24 @@ func (d *Details) Update(msg tea.Msg) (Viewer, tea.Cmd) {
 	)
 
 	switch msg := msg.(type) {
+	case LoadedRelatedArticle:
+		d.cancelLoad = nil
+		cmds = append(cmds,
+			func() tea.Msg { return UpdatedArticle(msg) },
+			func() tea.Msg { return ShowTextViewer{} },
+		)
+	case LoadingRelatedArticleFailed:
+		d.cancelLoad = nil
+		util.Logger.Println(msg.err)
+		d.status = errorText(d.shared.catalog, msg.err)
 	case UpdatedArticle:
-		d.SetArticle(tagesschau.Article(msg))
+		article := tagesschau.Article(msg)
+		if d.cancelLoad != nil && article.ID != d.loadingFor {
+			// the user moved on to another article
+			d.cancelLoad()
+			d.cancelLoad = nil
+		}
+		d.SetArticle(article)
 	}
 
 	if d.isActive {
@@ -49,26 +70,42 @@ func (d *Details) Update(msg tea.Msg) (Viewer, tea.Cmd) {
 	}
 	bv, cmd := d.BaseViewer.Update(msg)
 	cmds = append(cmds, cmd)
-	return &Details{BaseViewer: bv}, tea.Batch(cmds...)
+	return &Details{BaseViewer: bv, cancelLoad: d.cancelLoad, loadingFor: d.loadingFor}, tea.Batch(cmds...)
 }
 
 func (d *Details) handleNumberInput(number int) tea.Cmd {
 	related := d.shared.activeArticle.GetRelatedArticles()
 	index := number - 1
-	if 0 <= index && index < len(related) {
-		article, err := tagesschau.LoadArticle(related[index].Details)
-		if err == nil {
-			return tea.Batch(
-				func() tea.Msg { return UpdatedArticle(*article) },
-				func() tea.Msg { return ShowTextViewer{} },
-			)
+	if index < 0 || index >= len(related) {
+		return nil
+	}
+
+	if d.cancelLoad != nil {
+		d.cancelLoad()
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	d.cancelLoad = cancel
+	d.loadingFor = d.shared.activeArticle.ID
+	d.status = ""
+
+	client := d.shared.client
+	url := related[index].Details
+	return func() tea.Msg {
+		article, err := client.LoadArticle(ctx, url)
+		if ctx.Err() != nil {
+			// the load has been cancelled on purpose
+			return nil
+		}
+		if err != nil {
+			return LoadingRelatedArticleFailed{err: err}
 		}
+		return LoadedRelatedArticle(*article)
 	}
-	return nil
 }
 
 func (d *Details) SetArticle(article tagesschau.Article) {
 	d.SetHeaderData(article)
+	d.status = ""
 	d.viewport.SetContent(d.buildDetails(article))
 }
 
@@ -89,8 +126,7 @@ func (d Details) buildRegionalArticleDetails(article tagesschau.Article) string
 	regionStr := ""
 	regionsStr := ""
 	for _, regionID := range article.RegionIDs {
-		name, err := tagesschau.RegionIdToName(int(regionID))
-		if err == nil {
+		if name := d.shared.catalog.RegionName(regionID); name != "" {
 			if regionStr == "" {
 				regionStr = name
 			}
@@ -98,16 +134,16 @@ func (d Details) buildRegionalArticleDetails(article tagesschau.Article) string
 		}
 	}
 
-	strBuf := d.renderEntry("Titel", article.Desc) + "\n"
+	strBuf := d.renderEntry(d.shared.catalog.T(i18n.DetailTitle), article.Desc) + "\n"
 
 	if len(article.RegionIDs) == 1 {
-		strBuf += d.renderEntry("Region", regionStr) + "\n"
+		strBuf += d.renderEntry(d.shared.catalog.T(i18n.DetailRegion), regionStr) + "\n"
 	} else {
-		strBuf += d.shared.style.ActiveHighlightStyle.Render("Regionen:") + "\n"
+		strBuf += d.shared.style.ActiveHighlightStyle.Render(d.shared.catalog.T(i18n.DetailRegions)+":") + "\n"
 		strBuf += regionsStr
 	}
 	caser := cases.Title(language.English)
-	strBuf += d.renderEntry("Typ", caser.String(article.Type)) + "\n"
+	strBuf += d.renderEntry(d.shared.catalog.T(i18n.DetailType), caser.String(article.Type)) + "\n"
 	strBuf += d.breakingText(article.Breaking) + "\n"
 
 	return lipgloss.NewStyle().PaddingLeft(2).Render(strBuf)
@@ -128,18 +164,18 @@ func (d Details) buildNationalArticleDetails(article tagesschau.Article) string
 		relatedStr += ident + d.shared.style.InactiveStyle.Render(repr) + "\n"
 	}
 
-	strBuf := d.renderEntry("Titel", article.Topline) + "\n"
-	strBuf += d.renderEntry("Untertitel", article.Desc) + "\n"
+	strBuf := d.renderEntry(d.shared.catalog.T(i18n.DetailTitle), article.Topline) + "\n"
+	strBuf += d.renderEntry(d.shared.catalog.T(i18n.DetailSubtitle), article.Desc) + "\n"
 	caser := cases.Title(language.English)
 	if article.Ressort != "" {
-		strBuf += d.renderEntry("Ressort", caser.String(article.Ressort)) + "\n"
+		strBuf += d.renderEntry(d.shared.catalog.T(i18n.DetailRessort), caser.String(article.Ressort)) + "\n"
 	}
-	strBuf += d.renderEntry("Typ", caser.String(article.Type)) + "\n"
+	strBuf += d.renderEntry(d.shared.catalog.T(i18n.DetailType), caser.String(article.Type)) + "\n"
 	strBuf += d.breakingText(article.Breaking) + "\n"
-	strBuf += d.shared.style.ActiveHighlightStyle.Render("Tags:") + "\n"
+	strBuf += d.shared.style.ActiveHighlightStyle.Render(d.shared.catalog.T(i18n.DetailTags)+":") + "\n"
 	strBuf += tagStr
 	if len(relatedStr) > 0 {
-		strBuf += d.shared.style.ActiveHighlightStyle.Render("Verwandt:") + "\n"
+		strBuf += d.shared.style.ActiveHighlightStyle.Render(d.shared.catalog.T(i18n.DetailRelated)+":") + "\n"
 		strBuf += relatedStr
 	}
 
@@ -152,8 +188,8 @@ func (d Details) renderEntry(header, content string) string {
 
 func (d Details) breakingText(breaking bool) string {
 	if breaking {
-		return d.renderEntry("Eilmeldung", "Ja")
+		return d.renderEntry(d.shared.catalog.T(i18n.DetailBreaking), d.shared.catalog.T(i18n.Yes))
 	} else {
-		return d.renderEntry("Eilmeldung", "Nein")
+		return d.renderEntry(d.shared.catalog.T(i18n.DetailBreaking), d.shared.catalog.T(i18n.No))
 	}
 }
@@ -0,0 +1,159 @@
+package tui
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	matchStart        string = "\x1b[7m"
+	matchEnd          string = "\x1b[27m"
+	currentMatchStart string = "\x1b[4;7m"
+	currentMatchEnd   string = "\x1b[24;27m"
+)
+
+// findMatch is an occurrence of the search text, index counts the matches within the line
+type findMatch struct {
+	line  int
+	index int
+}
+
+// ansiToken is either an escape sequence or a single visible rune of a styled line
+type ansiToken struct {
+	text     string
+	isEscape bool
+}
+
+func tokenize(line string) []ansiToken {
+	var tokens []ansiToken
+	for i := 0; i < len(line); {
+		if line[i] == '\x1b' {
+			end := escapeEnd(line, i)
+			tokens = append(tokens, ansiToken{text: line[i:end], isEscape: true})
+			i = end
+			continue
+		}
+		_, size := utf8.DecodeRuneInString(line[i:])
+		tokens = append(tokens, ansiToken{text: line[i : i+size]})
+		i += size
+	}
+	return tokens
+}
+
+// escapeEnd returns the end of the escape sequence starting at the given position
+func escapeEnd(line string, start int) int {
+	if start+1 >= len(line) {
+		return len(line)
+	}
+	switch line[start+1] {
+	case '[':
+		// control sequences end with a byte in the range @ to ~
+		for i := start + 2; i < len(line); i++ {
+			if line[i] >= '@' && line[i] <= '~' {
+				return i + 1
+			}
+		}
+	case ']':
+		// operating system commands end with BEL or ESC \
+		for i := start + 2; i < len(line); i++ {
+			if line[i] == '\a' {
+				return i + 1
+			}
+			if line[i] == '\x1b' && i+1 < len(line) && line[i+1] == '\\' {
+				return i + 2
+			}
+		}
+	default:
+		return start + 2
+	}
+	return len(line)
+}
+
+// matchPositions returns the start of all non-overlapping, case-insensitive matches of the query among the visible runes
+func matchPositions(tokens []ansiToken, query []rune) []int {
+	var visible []rune
+	for _, token := range tokens {
+		if !token.isEscape {
+			r, _ := utf8.DecodeRuneInString(token.text)
+			visible = append(visible, unicode.ToLower(r))
+		}
+	}
+
+	var positions []int
+	for i := 0; i+len(query) <= len(visible); i++ {
+		if string(visible[i:i+len(query)]) == string(query) {
+			positions = append(positions, i)
+			i += len(query) - 1
+		}
+	}
+	return positions
+}
+
+func foldQuery(query string) []rune {
+	var folded []rune
+	for _, r := range query {
+		folded = append(folded, unicode.ToLower(r))
+	}
+	return folded
+}
+
+// findMatches returns all occurrences of the query within the styled lines
+func findMatches(lines []string, query string) []findMatch {
+	folded := foldQuery(query)
+	if len(folded) == 0 {
+		return nil
+	}
+
+	var matches []findMatch
+	for i, line := range lines {
+		for index := range matchPositions(tokenize(line), folded) {
+			matches = append(matches, findMatch{line: i, index: index})
+		}
+	}
+	return matches
+}
+
+// highlightLine marks all occurrences of the query within the styled line,
+// the match with the given index is emphasized
+func highlightLine(line string, query string, current int) string {
+	folded := foldQuery(query)
+	tokens := tokenize(line)
+	positions := matchPositions(tokens, folded)
+	if len(positions) == 0 {
+		return line
+	}
+
+	var sb strings.Builder
+	visibleIndex := 0
+	matchIndex := 0
+	inMatch := false
+	start, end := matchStart, matchEnd
+	for _, token := range tokens {
+		if token.isEscape {
+			sb.WriteString(token.text)
+			if inMatch {
+				// the styling of the text might have reset the highlight
+				sb.WriteString(start)
+			}
+			continue
+		}
+
+		if matchIndex < len(positions) && visibleIndex == positions[matchIndex] {
+			start, end = matchStart, matchEnd
+			if matchIndex == current {
+				start, end = currentMatchStart, currentMatchEnd
+			}
+			sb.WriteString(start)
+			inMatch = true
+		}
+		sb.WriteString(token.text)
+		visibleIndex++
+		if inMatch && visibleIndex == positions[matchIndex]+len(folded) {
+			sb.WriteString(end)
+			inMatch = false
+			matchIndex++
+		}
+	}
+	return sb.String()
+}
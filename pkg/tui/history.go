@@ -0,0 +1,60 @@
+package tui
+
+import "github.com/zMoooooritz/nachrichten/pkg/tagesschau"
+
+const (
+	maxHistorySize int = 100
+)
+
+// HistoryEntry remembers an opened article together with the state of the viewers
+type HistoryEntry struct {
+	article tagesschau.Article
+	viewer  ViewerType
+	offsets map[ViewerType]int
+}
+
+// History is a browser-like history of the articles opened from other articles
+type History struct {
+	back    []HistoryEntry
+	forward []HistoryEntry
+}
+
+func NewHistory() *History {
+	return &History{}
+}
+
+// Visit remembers the current entry before another article is opened
+func (h *History) Visit(current HistoryEntry) {
+	h.back = appendEntry(h.back, current)
+	h.forward = nil
+}
+
+// Back returns the previously visited entry, the current entry can be reached via Forward afterwards
+func (h *History) Back(current HistoryEntry) (HistoryEntry, bool) {
+	if len(h.back) == 0 {
+		return HistoryEntry{}, false
+	}
+	entry := h.back[len(h.back)-1]
+	h.back = h.back[:len(h.back)-1]
+	h.forward = appendEntry(h.forward, current)
+	return entry, true
+}
+
+// Forward returns the entry that was left via Back
+func (h *History) Forward(current HistoryEntry) (HistoryEntry, bool) {
+	if len(h.forward) == 0 {
+		return HistoryEntry{}, false
+	}
+	entry := h.forward[len(h.forward)-1]
+	h.forward = h.forward[:len(h.forward)-1]
+	h.back = appendEntry(h.back, current)
+	return entry, true
+}
+
+func appendEntry(entries []HistoryEntry, entry HistoryEntry) []HistoryEntry {
+	entries = append(entries, entry)
+	if len(entries) > maxHistorySize {
+		entries = entries[len(entries)-maxHistorySize:]
+	}
+	return entries
+}
@@ -1,14 +1,35 @@
 package tui
 
 import (
+	"context"
+	"slices"
+
+	"github.com/charmbracelet/bubbles/list"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/zMoooooritz/nachrichten/pkg/tagesschau"
+	"github.com/zMoooooritz/nachrichten/pkg/util"
+)
+
+const (
+	// the regional feeds are loaded if the homepage contains fewer articles of the selected regions
+	minRegionalArticles int = 10
 )
 
 type HomeSelector struct {
 	BaseSelector
 }
 
+func loadRegionalNews(client *tagesschau.Client, regions []tagesschau.RegionID) tea.Cmd {
+	return func() tea.Msg {
+		articles, err := client.LoadRegionalNews(context.Background(), regions)
+		if err != nil {
+			util.Logger.Println(err)
+			return nil
+		}
+		return LoadedRegionalNews{regions: regions, articles: articles}
+	}
+}
+
 func NewHomeSelector(selector BaseSelector) *HomeSelector {
 	return &HomeSelector{
 		BaseSelector: selector,
@@ -30,10 +51,34 @@ func (s *HomeSelector) Update(msg tea.Msg) (Selector, tea.Cmd) {
 		news := tagesschau.News(msg)
 		if s.selectorType == ST_NATIONAL {
 			s.articles = news.NationalNews
+			s.rebuildList()
 		} else if s.selectorType == ST_REGIONAL {
-			s.articles = news.RegionalNews
+			s.setArticles(s.regionalArticles(news))
+			cmds = append(cmds, s.loadRegionalNewsIfNeeded())
+		}
+	case RefreshedNews:
+		if s.selectorType == ST_NATIONAL {
+			s.replaceArticles(msg.NationalNews)
+		} else if s.selectorType == ST_REGIONAL {
+			s.replaceArticles(s.regionalArticles(tagesschau.News(msg)))
+			cmds = append(cmds, s.loadRegionalNewsIfNeeded())
+		}
+	case SelectedRegions:
+		if s.selectorType == ST_REGIONAL {
+			s.setArticles(s.regionalArticles(news))
+			cmds = append(cmds, s.loadRegionalNewsIfNeeded())
+			if s.isActive {
+				cmds = append(cmds, s.PushSelectedArticle())
+			}
+		}
+	case LoadedRegionalNews:
+		if s.selectorType == ST_REGIONAL && slices.Equal(msg.regions, s.shared.regions) {
+			wasEmpty := s.articles[0].IsEmptyArticle()
+			s.replaceArticles(appendArticles(s.regionalArticles(news), msg.articles))
+			if wasEmpty && s.isActive {
+				cmds = append(cmds, s.PushSelectedArticle())
+			}
 		}
-		s.rebuildList()
 	case tea.KeyMsg:
 		if s.isFocused && s.isVisible {
 			s.list, cmd = s.list.Update(msg)
@@ -46,6 +91,35 @@ func (s *HomeSelector) Update(msg tea.Msg) (Selector, tea.Cmd) {
 	return &HomeSelector{BaseSelector: bs}, tea.Batch(cmds...)
 }
 
+// regionalArticles returns the regional articles of the given news restricted to the selected regions
+func (s HomeSelector) regionalArticles(news tagesschau.News) []tagesschau.Article {
+	if len(s.shared.regions) == 0 {
+		return news.RegionalNews
+	}
+	return news.GetArticlesOfRegions(s.shared.regions)
+}
+
+// loadRegionalNewsIfNeeded fetches the feeds of the selected regions if the homepage does not offer enough articles
+func (s HomeSelector) loadRegionalNewsIfNeeded() tea.Cmd {
+	if len(s.shared.regions) == 0 || len(s.articles) >= minRegionalArticles {
+		return nil
+	}
+	return loadRegionalNews(s.shared.client, s.shared.regions)
+}
+
+// setArticles shows the given articles or an empty list if there are none
+func (s *HomeSelector) setArticles(articles []tagesschau.Article) {
+	if len(articles) == 0 {
+		s.articles = []tagesschau.Article{tagesschau.EMPTY_ARTICLE()}
+		s.list.SetItems([]list.Item{})
+		s.list.Select(0)
+		s.selectedIndex = 0
+		return
+	}
+	s.articles = articles
+	s.rebuildList()
+}
+
 func (s HomeSelector) View() string {
 	s.list.SetSize(s.width, s.height)
 
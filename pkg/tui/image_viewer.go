@@ -1,24 +1,55 @@
 package tui
 
 import (
+	"context"
+	"fmt"
 	"image"
+	"image/draw"
+	"math"
+	"strings"
 
+	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/reflow/truncate"
+	"github.com/zMoooooritz/nachrichten/pkg/config"
+	"github.com/zMoooooritz/nachrichten/pkg/i18n"
 	"github.com/zMoooooritz/nachrichten/pkg/tagesschau"
-	"github.com/zMoooooritz/nachrichten/pkg/util"
+)
+
+const (
+	zoomFactor = 1.5
+	maxZoom    = 8.0
+	// panStep is the part of the visible image the image is moved by
+	panStep = 0.2
 )
 
 type ImageViewer struct {
 	BaseViewer
-	image image.Image
+	image        image.Image
+	imageID      string
+	imageURL     string
+	thumbnailURL string
+	isLarge      bool
+	images       []tagesschau.ImageData
+	imageIndex   int
+	variants     tagesschau.ImageVariants
+	zoom         float64
+	centerX      float64
+	centerY      float64
+	cancelLoad   context.CancelFunc
+	loadingURL   string
+	wasActive    bool
 }
 
 func NewImageViewer(viewer BaseViewer) *ImageViewer {
-	viewer.modeName = "Bild"
+	viewer.modeName = viewer.shared.catalog.T(i18n.ModeImage)
 	return &ImageViewer{
 		BaseViewer: viewer,
 		image:      image.Rect(0, 0, 1, 1),
+		zoom:       1,
+		centerX:    0.5,
+		centerY:    0.5,
 	}
 }
 
@@ -30,42 +61,309 @@ func (i *ImageViewer) Update(msg tea.Msg) (Viewer, tea.Cmd) {
 
 	switch msg := msg.(type) {
 	case UpdatedArticle:
-		i.SetArticle(tagesschau.Article(msg))
+		article := tagesschau.Article(msg)
+		if article.ID != i.imageID {
+			i.resetZoom()
+		}
+		i.SetArticle(article)
+		if i.isActive {
+			cmds = append(cmds, i.loadImage(article))
+		}
+	case LoadedImage:
+		if !i.isActive {
+			break
+		}
+		if msg.id == i.imageKey(i.imageURL) {
+			i.cancelLoad = nil
+			i.isLarge = true
+			i.image = msg.image
+			i.pushImageToViewer(i.image)
+		} else if msg.id == i.imageID && !i.isLarge && i.isTeaser() {
+			i.image = msg.image
+			i.pushImageToViewer(i.image)
+		}
+	case tea.KeyMsg:
+		if i.isActive && (i.isFocused || i.isFullScreen) && i.shared.mode == NORMAL_MODE {
+			cmds = append(cmds, i.handleKey(msg))
+		}
 	}
 
-	if i.isFocused || i.isFullScreen {
+	if (i.isFocused || i.isFullScreen) && !i.shared.panning {
 		i.viewport, cmd = i.viewport.Update(msg)
 		cmds = append(cmds, cmd)
 	}
 	bv, cmd := i.BaseViewer.Update(msg)
 	cmds = append(cmds, cmd)
-	return &ImageViewer{BaseViewer: bv, image: i.image}, tea.Batch(cmds...)
+	i.BaseViewer = bv
+
+	if i.isActive && !i.wasActive {
+		// the image has not been loaded and drawn while the viewer was hidden
+		i.SetArticle(i.shared.activeArticle)
+		cmds = append(cmds, i.loadImage(i.shared.activeArticle))
+	}
+	i.wasActive = i.isActive
+
+	// the direction keys move the zoomed image instead of changing the focus
+	i.shared.panning = i.isActive && (i.isFocused || i.isFullScreen) && i.zoom > 1
+	return i, tea.Batch(cmds...)
+}
+
+func (i *ImageViewer) handleKey(msg tea.KeyMsg) tea.Cmd {
+	step := panStep / i.zoom
+	switch {
+	case key.Matches(msg, i.shared.keymap.next):
+		return i.selectImage(i.imageIndex + 1)
+	case key.Matches(msg, i.shared.keymap.prev):
+		return i.selectImage(i.imageIndex - 1)
+	case key.Matches(msg, i.shared.keymap.zoomIn):
+		i.zoom = min(i.zoom*zoomFactor, maxZoom)
+	case key.Matches(msg, i.shared.keymap.zoomOut):
+		i.zoom = max(i.zoom/zoomFactor, 1)
+	case i.zoom <= 1:
+		return nil
+	case key.Matches(msg, i.shared.keymap.left):
+		i.centerX -= step
+	case key.Matches(msg, i.shared.keymap.right):
+		i.centerX += step
+	case key.Matches(msg, i.shared.keymap.up):
+		i.centerY -= step
+	case key.Matches(msg, i.shared.keymap.down):
+		i.centerY += step
+	default:
+		return nil
+	}
+
+	i.clampCenter()
+	i.updateStatus()
+	i.pushImageToViewer(i.image)
+	return i.loadImage(i.shared.activeArticle)
+}
+
+// selectImage shows the image of the gallery with the given index
+func (i *ImageViewer) selectImage(index int) tea.Cmd {
+	if len(i.images) < 2 {
+		return nil
+	}
+	i.imageIndex = (index + len(i.images)) % len(i.images)
+	i.isLarge = false
+	i.resetZoom()
+	i.showImage()
+	return i.loadImage(i.shared.activeArticle)
+}
+
+func (i *ImageViewer) resetZoom() {
+	i.zoom = 1
+	i.centerX = 0.5
+	i.centerY = 0.5
+	i.updateStatus()
+}
+
+// clampCenter keeps the visible part within the image
+func (i *ImageViewer) clampCenter() {
+	half := 0.5 / i.zoom
+	i.centerX = max(half, min(i.centerX, 1-half))
+	i.centerY = max(half, min(i.centerY, 1-half))
+}
+
+// updateStatus shows the caption of the image as well as its position within the gallery and the zoom
+func (i *ImageViewer) updateStatus() {
+	var status []string
+	if len(i.images) > 1 {
+		status = append(status, fmt.Sprintf("%d/%d", i.imageIndex+1, len(i.images)))
+	}
+	if i.zoom > 1 {
+		status = append(status, fmt.Sprintf("%.f%%", i.zoom*100))
+	}
+	i.status = strings.Join(status, " ")
+
+	i.modeName = i.shared.catalog.T(i18n.ModeImage)
+	if i.imageIndex < len(i.images) {
+		image := i.images[i.imageIndex]
+		caption := image.Caption
+		if caption == "" {
+			caption = image.Title
+		}
+		if image.Copyright != "" {
+			caption = strings.TrimSpace(caption + " © " + image.Copyright)
+		}
+		if caption != "" {
+			// leave room for the status and the percentage
+			width := max(i.viewport.Width-lipgloss.Width(i.status)-8, 0)
+			i.modeName = truncate.StringWithTail(caption, uint(width), config.Ellipsis)
+		}
+	}
 }
 
 func (i *ImageViewer) SetArticle(article tagesschau.Article) {
 	i.SetHeaderData(article)
+	if article.ID != i.imageID {
+		i.isLarge = false
+		i.imageIndex = 0
+	}
+	i.imageID = article.ID
+	i.images = article.GetImages()
+	i.imageIndex = min(i.imageIndex, max(len(i.images)-1, 0))
+	i.thumbnailURL = thumbnailURL(article)
 	if article.IsEmptyArticle() {
+		i.images = nil
+		i.updateStatus()
 		i.viewport.SetContent("")
-	} else {
-		img := i.shared.imageCache.GetImage(article.ID, article.ImageData.ImageVariants.RectSmall)
-		i.pushImageToViewer(img)
+		return
+	}
+	if !i.isActive {
+		// images are only loaded and drawn while the viewer is shown
+		i.stopLoading()
+		i.imageURL = ""
+		i.updateStatus()
+		return
+	}
+	i.showImage()
+}
+
+func (i *ImageViewer) stopLoading() {
+	if i.cancelLoad != nil {
+		i.cancelLoad()
+		i.cancelLoad = nil
+	}
+}
+
+// showImage shows the selected image of the gallery
+func (i *ImageViewer) showImage() {
+	i.variants = tagesschau.ImageVariants{}
+	if i.imageIndex < len(i.images) {
+		i.variants = i.images[i.imageIndex].ImageVariants
+	}
+	i.updateStatus()
+
+	i.imageURL = i.preferredURL()
+	if img, found := i.shared.imageCache.GetCachedImage(i.imageKey(i.imageURL)); found {
+		i.image = img
+		i.isLarge = true
+	} else if !i.isLarge {
+		// show the thumbnail until the image of the required size is loaded
+		img, found := i.shared.imageCache.GetCachedImage(i.imageID)
+		if !found || !i.isTeaser() {
+			img = image.Rect(0, 0, 1, 1)
+		}
+		i.image = img
+	}
+	i.pushImageToViewer(i.image)
+}
+
+// preferredURL returns the url of the smallest image that fills the viewport at the current zoom,
+// or of the nearest available variant
+func (i *ImageViewer) preferredURL() string {
+	width, height := i.imageArea()
+	pixels, _ := i.shared.renderer.Resolution(width, height)
+	size := tagesschau.ImageSizeForWidth(int(math.Ceil(float64(pixels) * i.zoom)))
+	return tagesschau.GetNearestImageURL(i.variants, tagesschau.ImageSpec{Size: size, Ratio: tagesschau.RECT})
+}
+
+// isTeaser reports whether the selected image is the teaser image of the article, whose thumbnail is cached
+func (i *ImageViewer) isTeaser() bool {
+	return i.thumbnailURL != "" && tagesschau.GetImageURL(i.variants, tagesschau.ImageSpec{Size: tagesschau.SMALL, Ratio: tagesschau.RECT}) == i.thumbnailURL
+}
+
+// imageKey returns the key of the image within the image cache, thumbnails are cached by the id of their article
+func (i *ImageViewer) imageKey(url string) string {
+	if url == i.thumbnailURL {
+		return i.imageID
+	}
+	return url
+}
+
+func (i *ImageViewer) loadImage(article tagesschau.Article) tea.Cmd {
+	url := i.preferredURL()
+	if i.cancelLoad != nil && url == i.loadingURL {
+		// the image is already being loaded
+		return nil
+	}
+	// the previous image is no longer needed
+	i.stopLoading()
+	if article.IsEmptyArticle() || url == "" {
+		return nil
+	}
+
+	i.imageURL = url
+	key := i.imageKey(url)
+	if img, found := i.shared.imageCache.GetCachedImage(key); found {
+		if i.image != img {
+			i.image = img
+			i.isLarge = true
+			i.pushImageToViewer(img)
+		}
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	i.cancelLoad = cancel
+	i.loadingURL = url
+
+	imageCache := i.shared.imageCache
+	loadThumbnail := func() tea.Msg {
+		err := imageCache.LoadImage(ctx, article.ID, thumbnailURL(article))
+		if err != nil || ctx.Err() != nil {
+			return nil
+		}
+		img, _ := imageCache.GetCachedImage(article.ID)
+		return LoadedImage{id: article.ID, image: img}
+	}
+	if key == article.ID {
+		return loadThumbnail
+	}
+
+	cmds := []tea.Cmd{func() tea.Msg {
+		err := imageCache.LoadLargeImage(ctx, url)
+		if err != nil || ctx.Err() != nil {
+			return nil
+		}
+		img, _ := imageCache.GetCachedImage(url)
+		return LoadedImage{id: url, image: img}
+	}}
+	if _, found := imageCache.GetCachedImage(article.ID); !found && i.isTeaser() {
+		// the thumbnail is usually available sooner and also offline
+		cmds = append(cmds, loadThumbnail)
 	}
+	return tea.Batch(cmds...)
+}
+
+// imageArea returns the number of cells available for the image
+func (i *ImageViewer) imageArea() (int, int) {
+	return i.viewport.Width - 4, i.viewport.Height - 2
 }
 
 func (i *ImageViewer) pushImageToViewer(img image.Image) {
-	w := i.viewport.Width - 4
-	h := i.viewport.Height - 2
-	image := util.ImageToAscii(img, uint(w), uint(h), true)
+	w, h := i.imageArea()
+	rows := i.shared.renderer.Render(i.visiblePart(img), w, h)
 
 	strRepr := ""
-	for _, row := range image {
-		rowRepr := ""
-		for _, char := range row {
-			rowRepr += char
-		}
-		strRepr += lipgloss.PlaceHorizontal(i.viewport.Width, lipgloss.Center, rowRepr) + "\n"
+	for _, row := range rows {
+		strRepr += lipgloss.PlaceHorizontal(i.viewport.Width, lipgloss.Center, row) + "\n"
 	}
 
 	strRepr = lipgloss.PlaceVertical(h, lipgloss.Center, strRepr)
 	i.viewport.SetContent(strRepr)
 }
+
+// visiblePart crops the image to the part shown at the current zoom
+func (i *ImageViewer) visiblePart(img image.Image) image.Image {
+	if i.zoom <= 1 {
+		return img
+	}
+
+	bounds := img.Bounds()
+	width := max(int(float64(bounds.Dx())/i.zoom), 1)
+	height := max(int(float64(bounds.Dy())/i.zoom), 1)
+	left := bounds.Min.X + int(i.centerX*float64(bounds.Dx())) - width/2
+	top := bounds.Min.Y + int(i.centerY*float64(bounds.Dy())) - height/2
+	crop := image.Rect(left, top, left+width, top+height).Intersect(bounds)
+
+	if sub, ok := img.(interface {
+		SubImage(image.Rectangle) image.Image
+	}); ok {
+		return sub.SubImage(crop)
+	}
+	cropped := image.NewRGBA(image.Rect(0, 0, crop.Dx(), crop.Dy()))
+	draw.Draw(cropped, cropped.Bounds(), img, crop.Min, draw.Src)
+	return cropped
+}
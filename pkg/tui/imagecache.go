@@ -1,52 +1,134 @@
 package tui
 
 import (
+	"context"
 	"image"
+	"sync"
 
-	"github.com/zMoooooritz/nachrichten/pkg/http"
+	"github.com/zMoooooritz/nachrichten/pkg/storage"
 	"github.com/zMoooooritz/nachrichten/pkg/tagesschau"
 )
 
+const (
+	// maxLargeImages is the number of large images kept in memory
+	maxLargeImages = 5
+)
+
 type ImageCache struct {
-	images map[string]image.Image
+	client        *tagesschau.Client
+	snapshot      *storage.Snapshot
+	mutex         sync.RWMutex
+	images        map[string]image.Image
+	largeImages   []string
+	cancelPreload context.CancelFunc
+	cancelSave    context.CancelFunc
 }
 
-func NewImageCache() *ImageCache {
+func NewImageCache(client *tagesschau.Client, snapshot *storage.Snapshot) *ImageCache {
 	ic := ImageCache{
-		images: make(map[string]image.Image),
+		client:   client,
+		snapshot: snapshot,
+		images:   make(map[string]image.Image),
 	}
 	return &ic
 }
 
-func (ic *ImageCache) LoadImage(id, url string) error {
-	if _, found := ic.images[id]; found {
+func (ic *ImageCache) LoadImage(ctx context.Context, id, url string) error {
+	if _, found := ic.GetCachedImage(id); found {
 		return nil
 	}
-	img, err := http.LoadImage(url)
+	// prefer the persisted image, this also makes thumbnails available offline
+	img, err := ic.snapshot.LoadImage(id)
+	if err != nil {
+		img, err = ic.client.LoadImage(ctx, url)
+		if err == nil {
+			_ = ic.snapshot.SaveImage(id, img)
+		}
+	}
 	if err == nil {
+		ic.mutex.Lock()
 		ic.images[id] = img
+		ic.mutex.Unlock()
 	}
 	return err
 }
 
-func (ic *ImageCache) GetImage(id, url string) image.Image {
-	if img, found := ic.images[id]; found {
-		return img
+// LoadLargeImage loads an image of a higher resolution than the thumbnails,
+// these images are cached by their url, only the most recent ones are kept and none are persisted
+func (ic *ImageCache) LoadLargeImage(ctx context.Context, url string) error {
+	if _, found := ic.GetCachedImage(url); found {
+		return nil
 	}
-	err := ic.LoadImage(id, url)
-	if err == nil {
-		return ic.images[id]
+	img, err := ic.client.LoadImage(ctx, url)
+	if err != nil {
+		return err
 	}
-	return image.Rect(0, 0, 1, 1)
+
+	ic.mutex.Lock()
+	defer ic.mutex.Unlock()
+	if _, found := ic.images[url]; !found {
+		ic.largeImages = append(ic.largeImages, url)
+	}
+	ic.images[url] = img
+	for len(ic.largeImages) > maxLargeImages {
+		delete(ic.images, ic.largeImages[0])
+		ic.largeImages = ic.largeImages[1:]
+	}
+	return nil
 }
 
-func (ic *ImageCache) LoadThumbnails(articles []tagesschau.Article) {
+func (ic *ImageCache) GetCachedImage(id string) (image.Image, bool) {
+	ic.mutex.RLock()
+	defer ic.mutex.RUnlock()
+	img, found := ic.images[id]
+	return img, found
+}
+
+// PreloadThumbnails loads the thumbnails of the given articles in the background,
+// a preload that is still in progress is cancelled as it has been superseded.
+func (ic *ImageCache) PreloadThumbnails(articles []tagesschau.Article) {
+	ic.mutex.Lock()
+	if ic.cancelPreload != nil {
+		ic.cancelPreload()
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	ic.cancelPreload = cancel
+	ic.mutex.Unlock()
+
+	go ic.LoadThumbnails(ctx, articles)
+}
+
+// SaveThumbnails loads the thumbnails of the articles of the snapshot in the background, which persists them,
+// unlike a preload it is only superseded by the thumbnails of the next snapshot
+func (ic *ImageCache) SaveThumbnails(articles []tagesschau.Article) {
+	if ic.snapshot == nil {
+		return
+	}
+	ic.mutex.Lock()
+	if ic.cancelSave != nil {
+		ic.cancelSave()
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	ic.cancelSave = cancel
+	ic.mutex.Unlock()
+
+	go ic.LoadThumbnails(ctx, articles)
+}
+
+func (ic *ImageCache) LoadThumbnails(ctx context.Context, articles []tagesschau.Article) {
 	for _, article := range articles {
-		ic.LoadThumbnail(article)
+		if ctx.Err() != nil {
+			return
+		}
+		ic.LoadThumbnail(ctx, article)
 	}
 }
 
-func (ic *ImageCache) LoadThumbnail(article tagesschau.Article) {
+func (ic *ImageCache) LoadThumbnail(ctx context.Context, article tagesschau.Article) {
+	_ = ic.LoadImage(ctx, article.ID, thumbnailURL(article))
+}
+
+func thumbnailURL(article tagesschau.Article) string {
 	imageSpec := tagesschau.ImageSpec{Size: tagesschau.SMALL, Ratio: tagesschau.RECT}
-	_ = ic.LoadImage(article.ID, tagesschau.GetImageURL(article.ImageData.ImageVariants, imageSpec))
+	return tagesschau.GetImageURL(article.ImageData.ImageVariants, imageSpec)
 }
@@ -10,56 +10,80 @@ import (
 )
 
 type KeyMap struct {
-	quit      key.Binding
-	right     key.Binding
-	left      key.Binding
-	up        key.Binding
-	down      key.Binding
-	prev      key.Binding
-	next      key.Binding
-	full      key.Binding
-	start     key.Binding
-	end       key.Binding
-	pageUp    key.Binding
-	pageDown  key.Binding
-	search    key.Binding
-	confirm   key.Binding
-	escape    key.Binding
-	article   key.Binding
-	image     key.Binding
-	details   key.Binding
-	open      key.Binding
-	video     key.Binding
-	shortNews key.Binding
-	help      key.Binding
-	number    []key.Binding
+	quit        key.Binding
+	right       key.Binding
+	left        key.Binding
+	up          key.Binding
+	down        key.Binding
+	prev        key.Binding
+	next        key.Binding
+	full        key.Binding
+	start       key.Binding
+	end         key.Binding
+	pageUp      key.Binding
+	pageDown    key.Binding
+	search      key.Binding
+	confirm     key.Binding
+	escape      key.Binding
+	article     key.Binding
+	image       key.Binding
+	details     key.Binding
+	open        key.Binding
+	video       key.Binding
+	shortNews   key.Binding
+	breaking    key.Binding
+	regions     key.Binding
+	link        key.Binding
+	back        key.Binding
+	forward     key.Binding
+	bookmark    key.Binding
+	nextUnread  key.Binding
+	markAllRead key.Binding
+	findNext    key.Binding
+	findPrev    key.Binding
+	zoomIn      key.Binding
+	zoomOut     key.Binding
+	help        key.Binding
+	number      []key.Binding
 }
 
 func GetKeyMap(keys config.Keys) KeyMap {
 	return KeyMap{
-		quit:      toHelpBinding(keys.Quit, "quit"),
-		right:     toHelpBinding(keys.Right, "right"),
-		left:      toHelpBinding(keys.Left, "left"),
-		up:        toHelpBinding(keys.Up, "up"),
-		down:      toHelpBinding(keys.Down, "down"),
-		next:      toHelpBinding(keys.Next, "next"),
-		prev:      toHelpBinding(keys.Prev, "prev"),
-		full:      toHelpBinding(keys.Full, "full"),
-		start:     toHelpBinding(keys.Start, "start"),
-		end:       toHelpBinding(keys.End, "end"),
-		pageUp:    toHelpBinding(keys.PageUp, "pageup"),
-		pageDown:  toHelpBinding(keys.PageDown, "pagedown"),
-		search:    toHelpBinding(keys.Search, "search"),
-		confirm:   toHelpBinding(keys.Confirm, "confirm"),
-		escape:    toHelpBinding(keys.Escape, "escape"),
-		article:   toHelpBinding(keys.ShowArticle, "article"),
-		image:     toHelpBinding(keys.ShowThumbnail, "image"),
-		details:   toHelpBinding(keys.ShowDetails, "details"),
-		open:      toHelpBinding(keys.OpenArticle, "open"),
-		video:     toHelpBinding(keys.OpenVideo, "video"),
-		shortNews: toHelpBinding(keys.OpenShortNews, "shortnews"),
-		help:      toHelpBinding(keys.Help, "help"),
-		number:    getNumberBinds(),
+		quit:        toHelpBinding(keys.Quit, "quit"),
+		right:       toHelpBinding(keys.Right, "right"),
+		left:        toHelpBinding(keys.Left, "left"),
+		up:          toHelpBinding(keys.Up, "up"),
+		down:        toHelpBinding(keys.Down, "down"),
+		next:        toHelpBinding(keys.Next, "next"),
+		prev:        toHelpBinding(keys.Prev, "prev"),
+		full:        toHelpBinding(keys.Full, "full"),
+		start:       toHelpBinding(keys.Start, "start"),
+		end:         toHelpBinding(keys.End, "end"),
+		pageUp:      toHelpBinding(keys.PageUp, "pageup"),
+		pageDown:    toHelpBinding(keys.PageDown, "pagedown"),
+		search:      toHelpBinding(keys.Search, "search"),
+		confirm:     toHelpBinding(keys.Confirm, "confirm"),
+		escape:      toHelpBinding(keys.Escape, "escape"),
+		article:     toHelpBinding(keys.ShowArticle, "article"),
+		image:       toHelpBinding(keys.ShowThumbnail, "image"),
+		details:     toHelpBinding(keys.ShowDetails, "details"),
+		open:        toHelpBinding(keys.OpenArticle, "open"),
+		video:       toHelpBinding(keys.OpenVideo, "video"),
+		shortNews:   toHelpBinding(keys.OpenShortNews, "shortnews"),
+		breaking:    toHelpBinding(keys.ShowBreaking, "breaking"),
+		regions:     toHelpBinding(keys.SelectRegions, "regions"),
+		link:        toHelpBinding(keys.FollowLink, "link"),
+		back:        toHelpBinding(keys.Back, "back"),
+		forward:     toHelpBinding(keys.Forward, "forward"),
+		bookmark:    toHelpBinding(keys.Bookmark, "bookmark"),
+		nextUnread:  toHelpBinding(keys.NextUnread, "next unread"),
+		markAllRead: toHelpBinding(keys.MarkAllRead, "mark all read"),
+		findNext:    toHelpBinding(keys.FindNext, "next match"),
+		findPrev:    toHelpBinding(keys.FindPrev, "previous match"),
+		zoomIn:      toHelpBinding(keys.ZoomIn, "zoom in"),
+		zoomOut:     toHelpBinding(keys.ZoomOut, "zoom out"),
+		help:        toHelpBinding(keys.Help, "help"),
+		number:      getNumberBinds(),
 	}
 }
 
@@ -158,6 +182,6 @@ func (k KeyMap) ShortHelp() []key.Binding {
 func (k KeyMap) FullHelp() [][]key.Binding {
 	return [][]key.Binding{
 		{k.left, k.right, k.up, k.down, k.prev, k.next, k.help, k.quit},
-		{k.full, k.start, k.end, k.article, k.image, k.details, k.open, k.video, k.shortNews},
+		{k.full, k.start, k.end, k.article, k.image, k.details, k.open, k.video, k.shortNews, k.breaking, k.regions, k.link, k.back, k.forward, k.bookmark, k.nextUnread, k.markAllRead, k.findNext, k.findPrev, k.zoomIn, k.zoomOut},
 	}
 }
@@ -1,18 +1,24 @@
 package tui
 
 import (
+	"fmt"
+	"strings"
+	"time"
+
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/reflow/truncate"
+	"github.com/zMoooooritz/nachrichten/pkg/i18n"
+	"github.com/zMoooooritz/nachrichten/pkg/tagesschau"
+	"github.com/zMoooooritz/nachrichten/pkg/util"
 )
 
 const (
-	headerText         string = "Nachrichten"
-	regionalHeaderText string = "Regional"
-	nationalHeaderText string = "National"
-	searchHeaderText   string = "Suche"
-
-	selectorCount int = 3
+	nationalTab string = "national"
+	regionalTab string = "regional"
+	searchTab   string = "suche"
+	bookmarkTab string = "gemerkt"
 )
 
 type Navigator struct {
@@ -26,10 +32,18 @@ type Navigator struct {
 }
 
 func NewNavigator(shared *SharedState) *Navigator {
-	selectors := []Selector{
-		NewHomeSelector(NewSelector(ST_NATIONAL, shared, true)),
-		NewHomeSelector(NewSelector(ST_REGIONAL, shared, false)),
-		NewSearchSelector(NewSelector(ST_SEARCH, shared, false)),
+	var selectors []Selector
+	for _, tab := range shared.config.Settings.Tabs {
+		if selector := newTabSelector(tab, shared, len(selectors) == 0); selector != nil {
+			selectors = append(selectors, selector)
+		}
+	}
+	if len(selectors) == 0 {
+		selectors = []Selector{
+			NewHomeSelector(NewSelector(ST_NATIONAL, shared, true)),
+			NewHomeSelector(NewSelector(ST_REGIONAL, shared, false)),
+			NewSearchSelector(NewSelector(ST_SEARCH, shared, false)),
+		}
 	}
 
 	return &Navigator{
@@ -41,16 +55,54 @@ func NewNavigator(shared *SharedState) *Navigator {
 	}
 }
 
+// newTabSelector creates the selector for the given entry of Settings.Tabs, unknown tabs are ignored
+func newTabSelector(tab string, shared *SharedState, isActive bool) Selector {
+	tab = strings.ToLower(strings.TrimSpace(tab))
+	switch {
+	case tab == nationalTab:
+		return NewHomeSelector(NewSelector(ST_NATIONAL, shared, isActive))
+	case tab == regionalTab:
+		return NewHomeSelector(NewSelector(ST_REGIONAL, shared, isActive))
+	case tab == searchTab:
+		return NewSearchSelector(NewSelector(ST_SEARCH, shared, isActive))
+	case tab == bookmarkTab:
+		return NewBookmarkSelector(NewSelector(ST_BOOKMARKS, shared, isActive))
+	case tagesschau.IsValidRessort(tab):
+		return NewRessortSelector(NewSelector(ST_RESSORT, shared, isActive), tagesschau.Ressort(tab))
+	}
+	util.Logger.Printf("Unknown tab %q in the configuration\n", tab)
+	return nil
+}
+
 func (n *Navigator) nextSelector() {
-	n.gotoSelector((n.activeSelectorIndex + 1) % selectorCount)
+	n.gotoSelector((n.activeSelectorIndex + 1) % len(n.selectors))
 }
 
 func (n *Navigator) prevSelector() {
-	n.gotoSelector((selectorCount + n.activeSelectorIndex - 1) % selectorCount)
+	n.gotoSelector((len(n.selectors) + n.activeSelectorIndex - 1) % len(n.selectors))
 }
 
 func (n *Navigator) selectSearchSelector() {
-	n.gotoSelector(selectorCount - 1)
+	for i, selector := range n.selectors {
+		if selector.SelectorType() == ST_SEARCH {
+			n.gotoSelector(i)
+			return
+		}
+	}
+}
+
+// selectNextUnread selects the next unread article of the active tab or of the following tabs
+func (n *Navigator) selectNextUnread() bool {
+	for offset := range len(n.selectors) {
+		index := (n.activeSelectorIndex + offset) % len(n.selectors)
+		if n.selectors[index].SelectNextUnread() {
+			if index != n.activeSelectorIndex {
+				n.gotoSelector(index)
+			}
+			return true
+		}
+	}
+	return false
 }
 
 func (n *Navigator) gotoSelector(index int) {
@@ -79,6 +131,14 @@ func (n *Navigator) Update(msg tea.Msg) (*Navigator, tea.Cmd) {
 	)
 
 	switch msg := msg.(type) {
+	case SelectArticle:
+		for i, selector := range n.selectors {
+			if selector.SelectArticle(msg.id) {
+				n.gotoSelector(i)
+				cmds = append(cmds, selector.PushSelectedArticle())
+				break
+			}
+		}
 	case tea.KeyMsg:
 		if n.shared.mode == INSERT_MODE {
 			break
@@ -96,11 +156,11 @@ func (n *Navigator) Update(msg tea.Msg) (*Navigator, tea.Cmd) {
 				cmds = append(cmds, n.selectors[n.activeSelectorIndex].PushSelectedArticle())
 			}
 		case key.Matches(msg, n.shared.keymap.right):
-			if n.isVisible {
+			if n.isVisible && !n.shared.panning {
 				n.isFocused = false
 			}
 		case key.Matches(msg, n.shared.keymap.left):
-			if n.isVisible {
+			if n.isVisible && !n.shared.panning {
 				n.isFocused = true
 			}
 		case key.Matches(msg, n.shared.keymap.full):
@@ -109,6 +169,13 @@ func (n *Navigator) Update(msg tea.Msg) (*Navigator, tea.Cmd) {
 			if n.isFocused && n.isVisible {
 				n.selectSearchSelector()
 			}
+		case key.Matches(msg, n.shared.keymap.nextUnread):
+			if n.selectNextUnread() {
+				cmds = append(cmds, n.selectors[n.activeSelectorIndex].PushSelectedArticle())
+			}
+		case key.Matches(msg, n.shared.keymap.markAllRead):
+			n.selectors[n.activeSelectorIndex].MarkAllRead()
+			cmds = append(cmds, func() tea.Msg { return MarkedRead{} })
 		}
 	}
 
@@ -128,7 +195,15 @@ func (n Navigator) View() string {
 	}
 
 	headerView := n.headerView()
-	tabView := n.tabView([]string{nationalHeaderText, regionalHeaderText, searchHeaderText}, n.activeSelectorIndex)
+	var names []string
+	for _, selector := range n.selectors {
+		name := selector.Name()
+		if unread := selector.UnreadCount(); unread > 0 {
+			name += fmt.Sprintf(" (%d)", unread)
+		}
+		names = append(names, name)
+	}
+	tabView := n.tabView(names, n.activeSelectorIndex)
 
 	style := n.shared.style.ListInactiveStyle
 	if n.isFocused {
@@ -146,17 +221,22 @@ func (n Navigator) headerView() string {
 		headerStyle = n.shared.style.ListHeaderActiveStyle
 	}
 
-	centeredHeader := lipgloss.PlaceHorizontal(n.width, lipgloss.Center, headerText)
+	header := n.shared.catalog.T(i18n.HeaderTitle)
+	if !n.shared.offlineSince.IsZero() {
+		header += " – " + offlineText(n.shared.catalog, n.shared.offlineSince)
+	}
+
+	centeredHeader := lipgloss.PlaceHorizontal(n.width, lipgloss.Center, header)
 	return headerStyle.Render(centeredHeader)
 }
 
 func (n Navigator) tabView(names []string, activeIndex int) string {
-	cellWidth := (n.width - 2*len(names)) / len(names)
+	cellWidth := max((n.width-2*len(names))/len(names), 0)
 	var widths []int
 	for i := 0; i < len(names)-1; i++ {
 		widths = append(widths, cellWidth)
 	}
-	widths = append(widths, n.width-(len(names)-1)*cellWidth-2*len(names))
+	widths = append(widths, max(n.width-(len(names)-1)*cellWidth-2*len(names), 0))
 
 	result := ""
 	for i, name := range names {
@@ -166,8 +246,16 @@ func (n Navigator) tabView(names []string, activeIndex int) string {
 			border = n.shared.style.ActiveTabBorder
 			style = n.shared.style.TextHighlightStyle
 		}
-		centeredText := lipgloss.PlaceHorizontal(widths[i], lipgloss.Center, name)
+		centeredText := lipgloss.PlaceHorizontal(widths[i], lipgloss.Center, truncate.String(name, uint(widths[i])))
 		result = lipgloss.JoinHorizontal(lipgloss.Center, result, style.MarginBottom(1).BorderStyle(border).Render(centeredText))
 	}
 	return result
 }
+
+func offlineText(catalog i18n.Catalog, since time.Time) string {
+	format := catalog.T(i18n.TimeFormat)
+	if since.YearDay() != time.Now().YearDay() || since.Year() != time.Now().Year() {
+		format = catalog.T(i18n.DateTimeFormat)
+	}
+	return catalog.T(i18n.OfflineSince, since.Format(format))
+}
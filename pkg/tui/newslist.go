@@ -12,15 +12,22 @@ import (
 	"github.com/zMoooooritz/nachrichten/pkg/tagesschau"
 )
 
+const (
+	newArticleMarker string = "● "
+	bookmarkMarker   string = "★ "
+)
+
 type NewsDelegate struct {
 	Styles  config.Style
+	shared  *SharedState
 	height  int
 	spacing int
 }
 
-func NewNewsDelegate(s config.Style) NewsDelegate {
+func NewNewsDelegate(shared *SharedState) NewsDelegate {
 	return NewsDelegate{
-		Styles:  s,
+		Styles:  shared.style,
+		shared:  shared,
 		height:  2,
 		spacing: 1,
 	}
@@ -59,6 +66,12 @@ func (n NewsDelegate) Render(w io.Writer, m list.Model, index int, item list.Ite
 
 	// Prevent text from exceeding list width
 	textwidth := uint(m.Width() - s.ItemNormalTitle.GetPaddingLeft() - s.ItemNormalTitle.GetPaddingRight())
+	if n.shared.bookmarks.Contains(entry.ID) {
+		title = bookmarkMarker + title
+	}
+	if n.shared.newArticleIDs[entry.ID] {
+		title = newArticleMarker + title
+	}
 	title = truncate.StringWithTail(title, textwidth, config.Ellipsis)
 	var lines []string
 	for i, line := range strings.Split(desc, "\n") {
@@ -76,10 +89,15 @@ func (n NewsDelegate) Render(w io.Writer, m list.Model, index int, item list.Ite
 		desc = s.ItemBreakingDesc.Render(desc)
 	}
 
-	if isSelected {
+	switch {
+	case isSelected:
 		title = s.ItemSelectedTitle.Render(title)
 		desc = s.ItemSelectedDesc.Render(desc)
-	} else {
+	case !entry.Breaking && n.shared.read.Contains(entry.ID):
+		// read items are dimmed but keep the layout of normal items
+		title = s.ItemReadTitle.Render(title)
+		desc = s.ItemReadDesc.Render(desc)
+	default:
 		title = s.ItemNormalTitle.Render(title)
 		desc = s.ItemNormalDesc.Render(desc)
 	}
@@ -0,0 +1,116 @@
+package tui
+
+import (
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/reflow/truncate"
+	"github.com/zMoooooritz/nachrichten/pkg/config"
+	"github.com/zMoooooritz/nachrichten/pkg/i18n"
+	"github.com/zMoooooritz/nachrichten/pkg/tagesschau"
+)
+
+const (
+	breakingNewsTimeout time.Duration = time.Minute
+	// bellDuration is how long the bell stays part of the rendered frame, it rings once when the frame is written
+	bellDuration time.Duration = 500 * time.Millisecond
+)
+
+// Notification is a banner at the top of the screen announcing breaking news
+type Notification struct {
+	shared     *SharedState
+	article    tagesschau.Article
+	isVisible  bool
+	ringing    bool
+	generation int
+	width      int
+}
+
+func NewNotification(shared *SharedState) *Notification {
+	return &Notification{
+		shared: shared,
+	}
+}
+
+func (n *Notification) Show(article tagesschau.Article) tea.Cmd {
+	n.article = article
+	n.isVisible = true
+	n.generation++
+
+	generation := n.generation
+	cmds := []tea.Cmd{
+		tea.Tick(breakingNewsTimeout, func(time.Time) tea.Msg {
+			return DismissNotification{generation: generation}
+		}),
+	}
+	if n.shared.config.Settings.BreakingNewsBell {
+		// the bell is written by the renderer to not interfere with the output of the frames
+		n.ringing = true
+		cmds = append(cmds, tea.Tick(bellDuration, func(time.Time) tea.Msg {
+			return SilenceBell{generation: generation}
+		}))
+	}
+	return tea.Batch(cmds...)
+}
+
+func (n *Notification) SetWidth(width int) {
+	n.width = width
+}
+
+func (n *Notification) IsVisible() bool {
+	return n.isVisible
+}
+
+func (n *Notification) Update(msg tea.Msg) (*Notification, tea.Cmd) {
+	switch msg := msg.(type) {
+	case DismissNotification:
+		if msg.generation == n.generation {
+			n.isVisible = false
+		}
+	case SilenceBell:
+		if msg.generation == n.generation {
+			n.ringing = false
+		}
+	case tea.KeyMsg:
+		if n.shared.mode == INSERT_MODE || !n.isVisible {
+			break
+		}
+
+		switch {
+		case key.Matches(msg, n.shared.keymap.breaking):
+			n.isVisible = false
+			id := n.article.ID
+			return n, tea.Batch(
+				func() tea.Msg { return SelectArticle{id: id} },
+				func() tea.Msg { return ShowTextViewer{} },
+			)
+		}
+	}
+	return n, nil
+}
+
+func (n Notification) View() string {
+	if !n.isVisible {
+		return ""
+	}
+
+	style := n.shared.style.NotificationStyle
+	hint := n.shared.keymap.breaking.Help().Key + ": " + n.shared.catalog.T(i18n.OpenHint)
+	text := n.shared.catalog.T(i18n.BreakingNews) + ": " + n.article.Title()
+	if n.article.Desc != "" && n.article.Desc != n.article.Title() {
+		text += " – " + n.article.Desc
+	}
+
+	textWidth := max(0, n.width-style.GetHorizontalFrameSize()-lipgloss.Width(hint)-1)
+	text = truncate.StringWithTail(text, uint(textWidth), config.Ellipsis)
+	gap := max(1, n.width-style.GetHorizontalFrameSize()-lipgloss.Width(text)-lipgloss.Width(hint))
+
+	banner := style.Width(n.width).Render(text + strings.Repeat(" ", gap) + hint)
+	if n.ringing {
+		banner = "\a" + banner
+	}
+	return banner
+}
@@ -0,0 +1,121 @@
+package tui
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/zMoooooritz/nachrichten/pkg/i18n"
+	"github.com/zMoooooritz/nachrichten/pkg/tagesschau"
+)
+
+// RegionPicker is a dialog to choose the regions shown in the regional tab
+type RegionPicker struct {
+	shared    *SharedState
+	isVisible bool
+	cursor    int
+	selected  map[tagesschau.RegionID]bool
+	initial   map[tagesschau.RegionID]bool
+}
+
+func NewRegionPicker(shared *SharedState) *RegionPicker {
+	return &RegionPicker{
+		shared:   shared,
+		selected: make(map[tagesschau.RegionID]bool),
+	}
+}
+
+func (p *RegionPicker) Show() {
+	p.isVisible = true
+	p.cursor = 0
+	p.selected = make(map[tagesschau.RegionID]bool)
+	p.initial = make(map[tagesschau.RegionID]bool)
+	for _, region := range p.shared.regions {
+		p.selected[region] = true
+		p.initial[region] = true
+	}
+}
+
+func (p *RegionPicker) IsVisible() bool {
+	return p.isVisible
+}
+
+func (p *RegionPicker) Update(msg tea.Msg) (*RegionPicker, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if !p.isVisible {
+			break
+		}
+
+		regionCount := len(pickableRegions())
+		switch {
+		case key.Matches(msg, p.shared.keymap.up):
+			p.cursor = (p.cursor + regionCount - 1) % regionCount
+		case key.Matches(msg, p.shared.keymap.down):
+			p.cursor = (p.cursor + 1) % regionCount
+		case key.Matches(msg, p.shared.keymap.start):
+			p.cursor = 0
+		case key.Matches(msg, p.shared.keymap.end):
+			p.cursor = regionCount - 1
+		case key.Matches(msg, p.shared.keymap.confirm), msg.String() == " ":
+			region := pickableRegions()[p.cursor]
+			p.selected[region] = !p.selected[region]
+		case key.Matches(msg, p.shared.keymap.escape):
+			// discard the changes, the regions captured when the picker was opened stay selected
+			p.isVisible = false
+			p.selected = p.initial
+		case key.Matches(msg, p.shared.keymap.regions):
+			p.isVisible = false
+			regions := p.selectedRegions()
+			return p, func() tea.Msg { return SelectedRegions{regions: regions} }
+		}
+	}
+	return p, nil
+}
+
+func (p RegionPicker) selectedRegions() []tagesschau.RegionID {
+	regions := []tagesschau.RegionID{}
+	for _, region := range pickableRegions() {
+		if p.selected[region] {
+			regions = append(regions, region)
+		}
+	}
+	return regions
+}
+
+func (p RegionPicker) View() string {
+	if !p.isVisible {
+		return ""
+	}
+
+	var lines []string
+	for i, region := range pickableRegions() {
+		box := "[ ]"
+		if p.selected[region] {
+			box = "[x]"
+		}
+		line := box + " " + p.shared.catalog.RegionName(region)
+		if i == p.cursor {
+			line = p.shared.style.TextHighlightStyle.Render(line)
+		}
+		lines = append(lines, line)
+	}
+
+	title := p.shared.style.TextHighlightStyle.Bold(true).Render(p.shared.catalog.T(i18n.RegionPickerTitle))
+	hint := p.shared.style.ItemNormalDesc.UnsetPadding().Render(p.shared.catalog.T(i18n.RegionPickerHint, p.shared.keymap.regions.Help().Key, p.shared.keymap.escape.Help().Key))
+	return p.shared.style.DialogStyle.Render(lipgloss.JoinVertical(lipgloss.Left, title, "", strings.Join(lines, "\n"), "", hint))
+}
+
+// pickableRegions returns all federal states, Germany as a whole is not a region of its own
+func pickableRegions() []tagesschau.RegionID {
+	var regions []tagesschau.RegionID
+	for region := range tagesschau.GERMAN_NAMES {
+		if region != tagesschau.DE {
+			regions = append(regions, region)
+		}
+	}
+	slices.Sort(regions)
+	return regions
+}
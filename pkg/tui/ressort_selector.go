@@ -0,0 +1,106 @@
+package tui
+
+import (
+	"context"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/zMoooooritz/nachrichten/pkg/tagesschau"
+	"github.com/zMoooooritz/nachrichten/pkg/util"
+)
+
+type RessortSelector struct {
+	BaseSelector
+	ressort   tagesschau.Ressort
+	loaded    bool
+	err       error
+	wasActive bool
+}
+
+func loadRessort(client *tagesschau.Client, ressort tagesschau.Ressort) tea.Cmd {
+	return func() tea.Msg {
+		articles, err := client.LoadRessort(context.Background(), ressort)
+		if err != nil {
+			return LoadingRessortFailed{ressort: ressort, err: err}
+		}
+		return LoadedRessort{ressort: ressort, articles: articles}
+	}
+}
+
+func NewRessortSelector(selector BaseSelector, ressort tagesschau.Ressort) *RessortSelector {
+	selector.articles = []tagesschau.Article{tagesschau.EMPTY_ARTICLE()}
+	return &RessortSelector{
+		BaseSelector: selector,
+		ressort:      ressort,
+	}
+}
+
+func (s RessortSelector) Init() tea.Cmd {
+	return nil
+}
+
+func (s RessortSelector) Name() string {
+	return s.shared.catalog.RessortName(s.ressort)
+}
+
+func (s *RessortSelector) Update(msg tea.Msg) (Selector, tea.Cmd) {
+	var (
+		cmd  tea.Cmd
+		cmds []tea.Cmd
+	)
+
+	switch msg := msg.(type) {
+	case tagesschau.News, RefreshedNews:
+		cmds = append(cmds, loadRessort(s.shared.client, s.ressort))
+	case LoadingRessortFailed:
+		if msg.ressort == s.ressort {
+			util.Logger.Println(msg.err)
+			s.err = msg.err
+		}
+	case LoadedRessort:
+		if msg.ressort != s.ressort {
+			break
+		}
+		s.err = nil
+		if len(msg.articles) == 0 {
+			break
+		}
+		if s.loaded {
+			s.replaceArticles(msg.articles)
+		} else {
+			s.loaded = true
+			s.articles = msg.articles
+			s.rebuildList()
+			if s.isActive {
+				cmds = append(cmds, s.PushSelectedArticle())
+			}
+		}
+	case tea.KeyMsg:
+		if s.isFocused && s.isVisible {
+			s.list, cmd = s.list.Update(msg)
+			cmds = append(cmds, cmd)
+		}
+	}
+
+	bs, cmd := s.BaseSelector.Update(msg)
+	cmds = append(cmds, cmd)
+	s.BaseSelector = bs
+
+	if s.isActive && !s.wasActive && s.err != nil && !s.loaded {
+		// try again once the tab is entered after a failed load
+		cmds = append(cmds, loadRessort(s.shared.client, s.ressort))
+	}
+	s.wasActive = s.isActive
+	return s, tea.Batch(cmds...)
+}
+
+func (s RessortSelector) View() string {
+	if s.err == nil || s.loaded {
+		s.list.SetSize(s.width, s.height)
+		return s.list.View()
+	}
+
+	errorView := s.shared.style.ItemNormalDesc.Render(errorText(s.shared.catalog, s.err))
+	s.list.SetSize(s.width, s.height-lipgloss.Height(errorView))
+	return lipgloss.JoinVertical(lipgloss.Left, errorView, s.list.View())
+}
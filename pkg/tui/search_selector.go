@@ -1,33 +1,56 @@
 package tui
 
 import (
+	"context"
+
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/list"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/zMoooooritz/nachrichten/pkg/i18n"
 	"github.com/zMoooooritz/nachrichten/pkg/tagesschau"
+	"github.com/zMoooooritz/nachrichten/pkg/util"
+)
+
+const (
+	// the next page is loaded once the selection is this close to the end of the list
+	loadMoreThreshold int = 3
 )
 
 type SearchSelector struct {
 	BaseSelector
-	search textinput.Model
+	search       textinput.Model
+	cancelSearch context.CancelFunc
+	query        tagesschau.SearchQuery
+	result       tagesschau.SearchResult
+	loadingMore  bool
 }
 
-func loadArticles(seachTerm string) tea.Cmd {
+func loadArticles(ctx context.Context, client *tagesschau.Client, query tagesschau.SearchQuery, page int) tea.Cmd {
 	return func() tea.Msg {
-		searchResult, err := tagesschau.SearchArticles(seachTerm)
+		searchResult, err := client.SearchArticles(ctx, query, page, tagesschau.MaxSearchPageSize)
+		if ctx.Err() != nil {
+			// the search has been superseded by a newer one
+			return nil
+		}
+		if page > 0 {
+			if err != nil {
+				return LoadingMoreArticlesFailed{err: err}
+			}
+			return searchResult
+		}
 		if err == nil && len(searchResult.Articles) > 0 {
 			return searchResult
 		}
-		return LoadingArticlesFailed{}
+		return LoadingArticlesFailed{err: err}
 	}
 }
 
 func NewSearchSelector(selector BaseSelector) *SearchSelector {
 	searchInput := textinput.New()
 	searchInput.Prompt = ""
-	searchInput.Placeholder = "Suche ..."
+	searchInput.Placeholder = selector.shared.catalog.T(i18n.SearchPlaceholder)
 	searchInput.PromptStyle = selector.shared.style.ItemSelectedTitle
 	searchInput.Cursor.Style = selector.shared.style.InactiveStyle
 	searchInput.Cursor.TextStyle = selector.shared.style.InactiveStyle
@@ -52,18 +75,45 @@ func (s *SearchSelector) Update(msg tea.Msg) (Selector, tea.Cmd) {
 
 	switch msg := msg.(type) {
 	case LoadingArticlesFailed:
+		if msg.err != nil {
+			util.Logger.Println(msg.err)
+			s.search.Placeholder = errorText(s.shared.catalog, msg.err)
+		} else {
+			s.search.Placeholder = s.shared.catalog.T(i18n.NoResults)
+		}
+		s.search.Reset()
+		s.result = tagesschau.SearchResult{}
 		s.articles = []tagesschau.Article{tagesschau.EMPTY_ARTICLE()}
 		s.list.SetItems([]list.Item{})
 		s.selectedIndex = 0
 		cmds = append(cmds, s.PushSelectedArticle())
+	case InvalidSearchQuery:
+		s.search.Placeholder = queryErrorText(s.shared.catalog, msg.err)
+		s.search.Reset()
+	case LoadingMoreArticlesFailed:
+		util.Logger.Println(msg.err)
+		s.loadingMore = false
 	case tagesschau.SearchResult:
 		result := tagesschau.SearchResult(msg)
-		s.articles = result.Articles
-		s.rebuildList()
+		if result.ResultPage == 0 {
+			s.search.Placeholder = s.shared.catalog.T(i18n.SearchPlaceholder)
+			s.result = result
+			s.articles = result.Articles
+			s.rebuildList()
+			cmds = append(cmds, s.PushSelectedArticle())
+		} else {
+			s.loadingMore = false
+			s.result.ResultPage = result.ResultPage
+			s.result.TotalItemCount = result.TotalItemCount
+			s.result.FilteredOut += result.FilteredOut
+			// the API does not necessarily sort across the pages
+			articles := appendArticles(s.articles, result.Articles)
+			s.query.SortArticles(articles)
+			s.replaceArticles(articles)
+		}
 		if s.shared.config.Settings.PreloadThumbnails {
-			go s.shared.imageCache.LoadThumbnails(s.articles)
+			s.shared.imageCache.PreloadThumbnails(result.Articles)
 		}
-		cmds = append(cmds, s.PushSelectedArticle())
 	case tea.KeyMsg:
 		if s.isFocused && s.isVisible {
 			if s.shared.mode == NORMAL_MODE {
@@ -74,12 +124,14 @@ func (s *SearchSelector) Update(msg tea.Msg) (Selector, tea.Cmd) {
 					s.search.Reset()
 					bs, cmd := s.BaseSelector.Update(msg)
 					cmds = append(cmds, cmd)
-					return &SearchSelector{BaseSelector: bs, search: s.search}, tea.Batch(cmds...)
+					s.BaseSelector = bs
+					return s, tea.Batch(cmds...)
 				}
 			}
 			if s.shared.mode == NORMAL_MODE {
 				s.list, cmd = s.list.Update(msg)
 				cmds = append(cmds, cmd)
+				cmds = append(cmds, s.loadMoreIfNeeded())
 			}
 			if s.shared.mode == INSERT_MODE {
 				switch {
@@ -90,7 +142,7 @@ func (s *SearchSelector) Update(msg tea.Msg) (Selector, tea.Cmd) {
 				case key.Matches(msg, s.shared.keymap.confirm):
 					s.shared.mode = NORMAL_MODE
 					s.search.Blur()
-					cmds = append(cmds, loadArticles(s.search.Value()))
+					cmds = append(cmds, s.startSearch(s.search.Value()))
 				}
 			}
 		}
@@ -103,13 +155,78 @@ func (s *SearchSelector) Update(msg tea.Msg) (Selector, tea.Cmd) {
 
 	bs, cmd := s.BaseSelector.Update(msg)
 	cmds = append(cmds, cmd)
-	return &SearchSelector{BaseSelector: bs, search: s.search}, tea.Batch(cmds...)
+	s.BaseSelector = bs
+	return s, tea.Batch(cmds...)
+}
+
+func (s *SearchSelector) startSearch(input string) tea.Cmd {
+	query, err := tagesschau.ParseSearchQuery(input)
+	if err != nil {
+		return func() tea.Msg { return InvalidSearchQuery{err: err} }
+	}
+
+	if s.cancelSearch != nil {
+		s.cancelSearch()
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancelSearch = cancel
+	s.loadingMore = false
+	s.query = query
+	// the pages of the previous search must not be extended with the results of the new one
+	s.result = tagesschau.SearchResult{}
+	return loadArticles(ctx, s.shared.client, query, 0)
+}
+
+// loadMoreIfNeeded fetches the next page once the user scrolled close to the end of the list
+func (s *SearchSelector) loadMoreIfNeeded() tea.Cmd {
+	if s.loadingMore || !s.result.HasMorePages() || s.list.Index() < len(s.articles)-loadMoreThreshold {
+		return nil
+	}
+
+	if s.cancelSearch != nil {
+		s.cancelSearch()
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancelSearch = cancel
+	s.loadingMore = true
+	return loadArticles(ctx, s.shared.client, s.query, s.result.ResultPage+1)
+}
+
+func (s SearchSelector) statusView() string {
+	if s.result.TotalItemCount == 0 {
+		return ""
+	}
+	status := s.shared.catalog.T(i18n.ResultCount, len(s.articles), s.result.TotalItemCount)
+	if s.result.FilteredOut > 0 {
+		// the total of the API includes the results that did not match the filters
+		status = s.shared.catalog.T(i18n.ResultCountAtMost, len(s.articles), s.result.TotalItemCount)
+	}
+	if s.loadingMore {
+		status += " – " + s.shared.catalog.T(i18n.LoadingMore)
+	}
+	return s.shared.style.ItemNormalDesc.Render(status)
+}
+
+// appendArticles adds the articles of the next page while skipping duplicates
+func appendArticles(articles []tagesschau.Article, next []tagesschau.Article) []tagesschau.Article {
+	seen := make(map[string]bool)
+	for _, article := range articles {
+		seen[article.ID] = true
+	}
+	result := append([]tagesschau.Article{}, articles...)
+	for _, article := range next {
+		if !seen[article.ID] {
+			seen[article.ID] = true
+			result = append(result, article)
+		}
+	}
+	return result
 }
 
 func (s SearchSelector) View() string {
 	s.search.Width = s.width - 3
 
-	searchView := lipgloss.NewStyle().MarginBottom(1).Render(s.search.View())
+	searchView := lipgloss.JoinVertical(lipgloss.Left, s.search.View(), s.statusView())
 
 	s.list.SetSize(s.width, s.height-lipgloss.Height(searchView))
 
@@ -1,23 +1,57 @@
 package tui
 
 import (
+	"context"
+	"fmt"
+	"html"
+	"strconv"
 	"strings"
 
 	md "github.com/JohannesKaufmann/html-to-markdown"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/glamour"
+	"github.com/zMoooooritz/nachrichten/pkg/i18n"
 	"github.com/zMoooooritz/nachrichten/pkg/tagesschau"
 	"github.com/zMoooooritz/nachrichten/pkg/util"
 )
 
+// NumberTarget is the kind of item whose number is being entered
+type NumberTarget int
+
+const (
+	NT_NONE NumberTarget = iota
+	NT_LINK
+	NT_MEDIA
+)
+
 type Reader struct {
 	BaseViewer
+	links        []tagesschau.Link
+	numberTarget NumberTarget
+	numberInput  string
+	cancelLoad   context.CancelFunc
+	loadingFor   string
+	articleID    string
+	content      string
+	findInput    textinput.Model
+	finding      bool
+	matches      []findMatch
+	matchIndex   int
 }
 
 func NewReader(viewer BaseViewer) *Reader {
-	viewer.modeName = "Artikel"
+	viewer.modeName = viewer.shared.catalog.T(i18n.ModeArticle)
+
+	findInput := textinput.New()
+	findInput.Prompt = "/"
+	findInput.Cursor.Style = viewer.shared.style.InactiveStyle
+	findInput.Cursor.TextStyle = viewer.shared.style.InactiveStyle
+
 	return &Reader{
 		BaseViewer: viewer,
+		findInput:  findInput,
 	}
 }
 
@@ -28,8 +62,61 @@ func (r *Reader) Update(msg tea.Msg) (Viewer, tea.Cmd) {
 	)
 
 	switch msg := msg.(type) {
+	case LoadedLinkedArticle:
+		r.cancelLoad = nil
+		cmds = append(cmds, func() tea.Msg { return UpdatedArticle(msg) })
 	case UpdatedArticle:
-		r.SetArticle(tagesschau.Article(msg))
+		article := tagesschau.Article(msg)
+		if r.cancelLoad != nil && article.ID != r.loadingFor {
+			// the user moved on to another article
+			r.cancelLoad()
+			r.cancelLoad = nil
+		}
+		r.stopNumberInput()
+		if article.ID != r.articleID {
+			// the search does not carry over to other articles
+			r.stopFind()
+			r.findInput.Reset()
+		}
+		r.SetArticle(article)
+	case tea.KeyMsg:
+		if r.finding {
+			return r, r.handleFindInput(msg)
+		}
+		if r.numberTarget != NT_NONE {
+			return r, r.handleNumberInput(msg)
+		}
+		if r.isActive && r.shared.mode == NORMAL_MODE {
+			keyStr := msg.String()
+			if len(keyStr) == 1 && keyStr >= "0" && keyStr <= "9" && len(r.shared.activeArticle.GetMedia()) > 0 {
+				// the digit is the first one of the number of the media
+				r.startNumberInput(NT_MEDIA)
+				cmd = r.handleNumberInput(msg)
+				if r.numberInput == "" {
+					r.stopNumberInput()
+				}
+				return r, cmd
+			}
+			if r.isFocused || r.isFullScreen {
+				switch {
+				case key.Matches(msg, r.shared.keymap.link):
+					if len(r.links) > 0 {
+						r.startNumberInput(NT_LINK)
+						return r, nil
+					}
+				case key.Matches(msg, r.shared.keymap.search):
+					r.finding = true
+					r.shared.mode = INSERT_MODE
+					r.findInput.Reset()
+					r.updateFind()
+					return r, r.findInput.Focus()
+				case key.Matches(msg, r.shared.keymap.findNext):
+					r.gotoMatch(r.matchIndex + 1)
+				case key.Matches(msg, r.shared.keymap.findPrev):
+					r.gotoMatch(r.matchIndex - 1)
+				}
+			}
+		}
 	}
 
 	if r.IsFocused() || r.isFullScreen {
@@ -38,13 +125,248 @@ func (r *Reader) Update(msg tea.Msg) (Viewer, tea.Cmd) {
 	}
 	bv, cmd := r.BaseViewer.Update(msg)
 	cmds = append(cmds, cmd)
-	return &Reader{BaseViewer: bv}, tea.Batch(cmds...)
+	r.BaseViewer = bv
+	return r, tea.Batch(cmds...)
+}
+
+func (r *Reader) startNumberInput(target NumberTarget) {
+	r.numberTarget = target
+	r.numberInput = ""
+	r.shared.mode = INSERT_MODE
+	r.updateModeName()
+}
+
+// handleNumberInput reads the number of the link to follow or the media to open, the number
+// is accepted as soon as it is unambiguous or the input is confirmed
+func (r *Reader) handleNumberInput(msg tea.KeyMsg) tea.Cmd {
+	target := r.numberTarget
+	count := len(r.links)
+	if target == NT_MEDIA {
+		count = len(r.shared.activeArticle.GetMedia())
+	}
+
+	keyStr := msg.String()
+	switch {
+	case key.Matches(msg, r.shared.keymap.escape):
+		r.stopNumberInput()
+		return nil
+	case key.Matches(msg, r.shared.keymap.confirm):
+		number, _ := strconv.Atoi(r.numberInput)
+		r.stopNumberInput()
+		return r.acceptNumber(target, number)
+	case len(keyStr) == 1 && keyStr >= "0" && keyStr <= "9":
+		number, _ := strconv.Atoi(r.numberInput + keyStr)
+		if number == 0 || number > count {
+			return nil
+		}
+		r.numberInput += keyStr
+		if number*10 > count {
+			r.stopNumberInput()
+			return r.acceptNumber(target, number)
+		}
+		r.updateModeName()
+	}
+	return nil
+}
+
+func (r *Reader) acceptNumber(target NumberTarget, number int) tea.Cmd {
+	if target == NT_MEDIA {
+		return r.openMedia(number)
+	}
+	return r.followLink(number)
+}
+
+// handleFindInput searches the article incrementally while the search text is typed
+func (r *Reader) handleFindInput(msg tea.KeyMsg) tea.Cmd {
+	switch {
+	case key.Matches(msg, r.shared.keymap.escape):
+		r.stopFind()
+		r.findInput.Reset()
+		r.updateFind()
+		return nil
+	case key.Matches(msg, r.shared.keymap.confirm):
+		r.stopFind()
+		return nil
+	}
+
+	var cmd tea.Cmd
+	r.findInput, cmd = r.findInput.Update(msg)
+	r.updateFind()
+	return cmd
+}
+
+func (r *Reader) stopFind() {
+	if !r.finding {
+		return
+	}
+	r.finding = false
+	r.findInput.Blur()
+	r.shared.mode = NORMAL_MODE
+	r.updateModeName()
+}
+
+// updateFind searches the text again and shows the first match below the current position
+func (r *Reader) updateFind() {
+	r.matches = findMatches(strings.Split(r.content, "\n"), r.findInput.Value())
+	r.matchIndex = 0
+	for i, match := range r.matches {
+		if match.line >= r.viewport.YOffset {
+			r.matchIndex = i
+			break
+		}
+	}
+	r.updateModeName()
+	r.showMatches()
+	r.scrollToMatch()
+}
+
+func (r *Reader) gotoMatch(index int) {
+	if len(r.matches) == 0 {
+		return
+	}
+	r.matchIndex = (index + len(r.matches)) % len(r.matches)
+	r.updateModeName()
+	r.showMatches()
+	r.scrollToMatch()
+}
+
+// showMatches highlights all matches within the rendered article
+func (r *Reader) showMatches() {
+	if len(r.matches) == 0 {
+		r.viewport.SetContent(r.content)
+		return
+	}
+
+	lines := strings.Split(r.content, "\n")
+	current := r.matches[r.matchIndex]
+	highlighted := make(map[int]bool)
+	for _, match := range r.matches {
+		if highlighted[match.line] {
+			continue
+		}
+		highlighted[match.line] = true
+		currentIndex := -1
+		if match.line == current.line {
+			currentIndex = current.index
+		}
+		lines[match.line] = highlightLine(lines[match.line], r.findInput.Value(), currentIndex)
+	}
+	r.viewport.SetContent(strings.Join(lines, "\n"))
+}
+
+func (r *Reader) scrollToMatch() {
+	if len(r.matches) == 0 {
+		return
+	}
+	line := r.matches[r.matchIndex].line
+	if line < r.viewport.YOffset || line >= r.viewport.YOffset+r.viewport.Height {
+		r.viewport.SetYOffset(line - r.viewport.Height/2)
+	}
+}
+
+func (r *Reader) stopNumberInput() {
+	if r.numberTarget == NT_NONE {
+		return
+	}
+	r.numberTarget = NT_NONE
+	r.numberInput = ""
+	r.shared.mode = NORMAL_MODE
+	r.updateModeName()
+}
+
+func (r *Reader) updateModeName() {
+	switch {
+	case r.numberTarget == NT_LINK:
+		r.modeName = r.shared.catalog.T(i18n.LinkMode, r.numberInput)
+	case r.numberTarget == NT_MEDIA:
+		r.modeName = r.shared.catalog.T(i18n.MediaMode, r.numberInput)
+	case r.finding:
+		r.modeName = r.findInput.View()
+	default:
+		r.modeName = r.shared.catalog.T(i18n.ModeArticle)
+	}
+
+	r.status = ""
+	if r.findInput.Value() != "" {
+		current := 0
+		if len(r.matches) > 0 {
+			current = r.matchIndex + 1
+		}
+		r.status = fmt.Sprintf("%d/%d", current, len(r.matches))
+	}
+}
+
+// followLink opens links to tagesschau articles in the reader and all other links externally
+func (r *Reader) followLink(number int) tea.Cmd {
+	index := number - 1
+	if index < 0 || index >= len(r.links) {
+		return nil
+	}
+
+	link := r.links[index].URL
+	url, isArticle := r.shared.client.ArticleAPIURL(link)
+	if !isArticle {
+		return func() tea.Msg { return OpenLink(link) }
+	}
+
+	if r.cancelLoad != nil {
+		r.cancelLoad()
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	r.cancelLoad = cancel
+	r.loadingFor = r.shared.activeArticle.ID
+
+	client := r.shared.client
+	return func() tea.Msg {
+		article, err := client.LoadArticle(ctx, url)
+		if ctx.Err() != nil {
+			return nil
+		}
+		if err != nil || article.IsEmptyArticle() || len(article.Content) == 0 {
+			// not every page of tagesschau.de is available as article
+			return OpenLink(link)
+		}
+		return LoadedLinkedArticle(*article)
+	}
+}
+
+// openMedia opens the media with the given number
+func (r *Reader) openMedia(number int) tea.Cmd {
+	media := r.shared.activeArticle.GetMedia()
+	index := number - 1
+	if index < 0 || index >= len(media) {
+		return nil
+	}
+	return func() tea.Msg {
+		return OpenMedia(media[index])
+	}
 }
 
 func (r *Reader) SetArticle(article tagesschau.Article) {
 	r.SetHeaderData(article)
-	paragraphs := tagesschau.ContentToParagraphs(article.Content)
-	r.viewport.SetContent(r.formatParagraphs(paragraphs))
+	paragraphs, links := tagesschau.ContentToParagraphs(article.Content, r.mediaPlaceholder)
+	r.links = links
+	if len(links) > 0 {
+		paragraphs = append(paragraphs, r.footnotes(links))
+	}
+	r.articleID = article.ID
+	r.content = r.formatParagraphs(paragraphs)
+	r.matches = findMatches(strings.Split(r.content, "\n"), r.findInput.Value())
+	r.matchIndex = min(r.matchIndex, max(len(r.matches)-1, 0))
+	r.updateModeName()
+	r.showMatches()
+}
+
+func (r Reader) footnotes(links []tagesschau.Link) string {
+	var lines []string
+	for i, link := range links {
+		lines = append(lines, fmt.Sprintf("[%d] %s", i+1, html.EscapeString(link.URL)))
+	}
+	return "<p><strong>" + r.shared.catalog.T(i18n.Links) + "</strong></p><p>" + strings.Join(lines, "<br />") + "</p>"
+}
+
+func (r Reader) mediaPlaceholder(index int, media tagesschau.Media) string {
+	return r.shared.catalog.T(i18n.MediaPlaceholder, r.shared.catalog.MediaName(media.Type), media.Title, index)
 }
 
 func (r Reader) formatParagraphs(paragraphs []string) string {
@@ -59,19 +381,19 @@ func (r Reader) formatParagraphs(paragraphs []string) string {
 	)
 	if err != nil {
 		util.Logger.Fatalln(err)
-		return util.PadText("Unable to parse and print article", width)
+		return util.PadText(r.shared.catalog.T(i18n.UnparsableArticle), width)
 	}
 
 	joined := strings.Join(paragraphs, "\n\n")
 	text, err := converter.ConvertString(joined)
 	if err != nil {
 		util.Logger.Fatalln(err)
-		return util.PadText("Unable to parse and print article", width)
+		return util.PadText(r.shared.catalog.T(i18n.UnparsableArticle), width)
 	}
 	result, err := renderer.Render(text)
 	if err != nil {
 		util.Logger.Fatalln(err)
-		return util.PadText("Unable to parse and print article", width)
+		return util.PadText(r.shared.catalog.T(i18n.UnparsableArticle), width)
 	}
 	return util.PadText(result, width)
 }
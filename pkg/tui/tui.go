@@ -1,19 +1,29 @@
 package tui
 
 import (
+	"context"
 	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/spinner"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/zMoooooritz/nachrichten/pkg/config"
+	"github.com/zMoooooritz/nachrichten/pkg/graphics"
+	"github.com/zMoooooritz/nachrichten/pkg/http"
+	"github.com/zMoooooritz/nachrichten/pkg/i18n"
+	"github.com/zMoooooritz/nachrichten/pkg/storage"
 	"github.com/zMoooooritz/nachrichten/pkg/tagesschau"
 	"github.com/zMoooooritz/nachrichten/pkg/util"
 )
 
 const (
-	germanDateFormat string = "15:04 02.01.06"
+	regionsFileName string = "regions.json"
+	// readSaveDelay is the time without newly read articles after which the read articles are saved
+	readSaveDelay time.Duration = 5 * time.Second
 )
 
 var (
@@ -21,16 +31,22 @@ var (
 )
 
 type Model struct {
-	opener        util.Opener
-	ready         bool
-	loadingFailed bool
-	shared        *SharedState
-	navigator     *Navigator
-	viewManager   *ViewManager
-	helper        *Helper
-	spinner       spinner.Model
-	width         int
-	height        int
+	opener       util.Opener
+	ready        bool
+	loadingError error
+	retryStatus  RetryingNews
+	retries      chan RetryingNews
+	snapshot     *storage.Snapshot
+	shared       *SharedState
+	navigator    *Navigator
+	viewManager  *ViewManager
+	helper       *Helper
+	notification *Notification
+	regionPicker *RegionPicker
+	spinner      spinner.Model
+	readSaves    int
+	width        int
+	height       int
 }
 
 type Mode int
@@ -48,9 +64,20 @@ type SharedState struct {
 	config        config.Configuration
 	activeArticle tagesschau.Article
 	imageCache    *ImageCache
+	renderer      graphics.Renderer
+	client        *tagesschau.Client
+	offlineSince  time.Time
+	newArticleIDs map[string]bool
+	regions       []tagesschau.RegionID
+	catalog       i18n.Catalog
+	history       *History
+	bookmarks     *storage.Bookmarks
+	read          *storage.IDSet
+	// panning is set while the direction keys move the zoomed image instead of the focus
+	panning bool
 }
 
-func InitialModel(c config.Configuration) Model {
+func InitialModel(c config.Configuration, client *tagesschau.Client, snapshot *storage.Snapshot, bookmarks *storage.Bookmarks, read *storage.IDSet) Model {
 	initialHelpState := HS_NORMAL
 	if c.Settings.HideHelpOnStartup {
 		initialHelpState = HS_HIDDEN
@@ -58,27 +85,168 @@ func InitialModel(c config.Configuration) Model {
 
 	style := config.NewsStyle(c.Theme)
 	shared := &SharedState{
-		mode:       NORMAL_MODE,
-		style:      style,
-		keys:       c.Keys,
-		keymap:     GetKeyMap(c.Keys),
-		config:     c,
-		imageCache: NewImageCache(),
+		mode:          NORMAL_MODE,
+		style:         style,
+		keys:          c.Keys,
+		keymap:        GetKeyMap(c.Keys),
+		config:        c,
+		imageCache:    NewImageCache(client, snapshot),
+		renderer:      newRenderer(c.Settings),
+		client:        client,
+		newArticleIDs: make(map[string]bool),
+		regions:       loadRegions(c.Settings.Regions),
+		catalog:       i18n.NewCatalog(i18n.DetectLanguage(c.Settings.Language)),
+		history:       NewHistory(),
+		bookmarks:     bookmarks,
+		read:          read,
 	}
 
 	return Model{
-		opener:      util.NewOpener(c.Applications),
-		ready:       false,
-		helper:      NewHelper(shared, initialHelpState),
-		navigator:   NewNavigator(shared),
-		shared:      shared,
-		viewManager: NewViewManager(shared),
-		spinner:     NewDotSpinner(),
+		opener:       util.NewOpener(c.Applications),
+		ready:        false,
+		helper:       NewHelper(shared, initialHelpState),
+		notification: NewNotification(shared),
+		regionPicker: NewRegionPicker(shared),
+		navigator:    NewNavigator(shared),
+		shared:       shared,
+		viewManager:  NewViewManager(shared),
+		spinner:      NewDotSpinner(),
+		retries:      make(chan RetryingNews),
+		snapshot:     snapshot,
+	}
+}
+
+func newRenderer(settings config.Settings) graphics.Renderer {
+	protocol := graphics.Protocol(strings.ToLower(settings.ImageProtocol))
+	if !graphics.IsValidProtocol(protocol) {
+		util.Logger.Printf("Unknown image protocol %s in the configuration\n", settings.ImageProtocol)
+		protocol = graphics.IP_AUTO
+	}
+	mode := graphics.ImageMode(strings.ToLower(settings.ImageMode))
+	if !graphics.IsValidImageMode(mode) {
+		util.Logger.Printf("Unknown image mode %s in the configuration\n", settings.ImageMode)
+		mode = graphics.IM_ASCII
+	}
+	depth := graphics.ColorDepth(strings.ToLower(settings.ImageColors))
+	if !graphics.IsValidColorDepth(depth) {
+		util.Logger.Printf("Unknown image colors %s in the configuration\n", settings.ImageColors)
+		depth = graphics.CD_AUTO
+	}
+	return graphics.NewRenderer(protocol, mode, depth)
+}
+
+// loadRegions returns the regions chosen within the application or the configured ones if none were chosen yet
+func loadRegions(configured []int) []tagesschau.RegionID {
+	regions := []tagesschau.RegionID{}
+	if dataDir, err := storage.DataDir(); err == nil {
+		if storage.LoadJSON(filepath.Join(dataDir, regionsFileName), &regions) == nil {
+			return regions
+		}
+	}
+
+	for _, id := range configured {
+		if tagesschau.IsValidRegion(id) {
+			regions = append(regions, tagesschau.RegionID(id))
+		} else {
+			util.Logger.Printf("Unknown region %d in the configuration\n", id)
+		}
+	}
+	return regions
+}
+
+func saveRegions(regions []tagesschau.RegionID) tea.Cmd {
+	return func() tea.Msg {
+		dataDir, err := storage.DataDir()
+		if err == nil {
+			err = storage.SaveJSON(filepath.Join(dataDir, regionsFileName), regions)
+		}
+		if err != nil {
+			util.Logger.Println(err)
+		}
+		return nil
+	}
+}
+
+// scheduleReadSave saves the read articles once no further articles have been read for a while
+func (m *Model) scheduleReadSave() tea.Cmd {
+	m.readSaves++
+	id := m.readSaves
+	return tea.Tick(readSaveDelay, func(time.Time) tea.Msg {
+		return SaveReadArticles{id: id}
+	})
+}
+
+func saveReadArticles(read *storage.IDSet) tea.Cmd {
+	return func() tea.Msg {
+		if err := read.Save(); err != nil {
+			util.Logger.Println(err)
+		}
+		return nil
+	}
+}
+
+// loadShortNews looks up the current short news, the video is opened once it has been found
+func loadShortNews(client *tagesschau.Client) tea.Cmd {
+	return func() tea.Msg {
+		url, err := client.GetShortNewsURL(context.Background())
+		if err != nil {
+			util.Logger.Println(err)
+			return nil
+		}
+		return OpenMedia(tagesschau.Media{Type: tagesschau.MT_VIDEO, URL: url})
+	}
+}
+
+func saveBookmarks(bookmarks *storage.Bookmarks) tea.Cmd {
+	return func() tea.Msg {
+		if err := bookmarks.Save(); err != nil {
+			util.Logger.Println(err)
+		}
+		return UpdatedBookmarks{}
 	}
 }
 
 func (m Model) Init() tea.Cmd {
-	return tea.Batch(loadNews, m.spinner.Tick)
+	return tea.Batch(
+		loadNews(m.shared.client, m.snapshot, m.retries),
+		waitForRetry(m.retries),
+		m.spinner.Tick,
+		m.scheduleRefresh(),
+	)
+}
+
+func (m Model) scheduleRefresh() tea.Cmd {
+	interval := m.shared.config.Settings.RefreshInterval
+	if interval <= 0 {
+		return nil
+	}
+	return tea.Tick(interval, func(time.Time) tea.Msg {
+		return RefreshNews{}
+	})
+}
+
+func refreshNews(client *tagesschau.Client, snapshot *storage.Snapshot) tea.Cmd {
+	return func() tea.Msg {
+		news, err := client.LoadNews(context.Background())
+		if err != nil {
+			return RefreshingNewsFailed{err: err}
+		}
+		if saveErr := snapshot.SaveNews(news); saveErr != nil {
+			util.Logger.Println(saveErr)
+		}
+		return RefreshedNews(news)
+	}
+}
+
+// loadThumbnails persists the thumbnails of the news along with the snapshot,
+// without a snapshot they are only preloaded if configured
+func (m Model) loadThumbnails(news tagesschau.News) {
+	articles := append(news.NationalNews, news.RegionalNews...)
+	if m.snapshot != nil {
+		m.shared.imageCache.SaveThumbnails(articles)
+	} else if m.shared.config.Settings.PreloadThumbnails {
+		m.shared.imageCache.PreloadThumbnails(articles)
+	}
 }
 
 func refreshFunc(article tagesschau.Article) tea.Cmd {
@@ -87,12 +255,39 @@ func refreshFunc(article tagesschau.Article) tea.Cmd {
 	}
 }
 
-func loadNews() tea.Msg {
-	news, err := tagesschau.LoadNews()
-	if err == nil {
-		return news
+func loadNews(client *tagesschau.Client, snapshot *storage.Snapshot, retries chan<- RetryingNews) tea.Cmd {
+	return func() tea.Msg {
+		defer close(retries)
+		ctx := http.WithRetryNotifier(context.Background(), func(attempt, attempts int, err error) {
+			util.Logger.Println(err)
+			retries <- RetryingNews{attempt: attempt, attempts: attempts}
+		})
+		news, err := client.LoadNews(ctx)
+		if err == nil {
+			if saveErr := snapshot.SaveNews(news); saveErr != nil {
+				util.Logger.Println(saveErr)
+			}
+			return news
+		}
+
+		// fall back to the news of the last successful start
+		offlineNews, timestamp, snapshotErr := snapshot.LoadNews()
+		if snapshotErr == nil {
+			util.Logger.Println(err)
+			return OfflineNews{news: offlineNews, timestamp: timestamp}
+		}
+		return LoadingNewsFailed{err: err}
+	}
+}
+
+func waitForRetry(retries <-chan RetryingNews) tea.Cmd {
+	return func() tea.Msg {
+		retry, ok := <-retries
+		if !ok {
+			return nil
+		}
+		return retry
 	}
-	return LoadingNewsFailed{}
 }
 
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
@@ -102,23 +297,67 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	)
 
 	switch msg := msg.(type) {
+	case RetryingNews:
+		m.retryStatus = msg
+		cmds = append(cmds, waitForRetry(m.retries))
+	case OfflineNews:
+		m.shared.offlineSince = msg.timestamp
+		cmds = append(cmds, func() tea.Msg { return msg.news })
 	case LoadingNewsFailed:
-		m.loadingFailed = true
+		util.Logger.Println(msg.err)
+		m.loadingError = msg.err
 	case tagesschau.News:
 		news = tagesschau.News(msg)
-		if m.shared.config.Settings.PreloadThumbnails {
-			go m.shared.imageCache.LoadThumbnails(append(news.NationalNews, news.RegionalNews...))
-		}
+		m.loadThumbnails(news)
 		m.ready = true
 		m.shared.activeArticle = news.NationalNews[0]
 		cmds = append(cmds, refreshFunc(m.shared.activeArticle))
+	case RefreshNews:
+		cmds = append(cmds, refreshNews(m.shared.client, m.snapshot))
+	case RefreshingNewsFailed:
+		util.Logger.Println(msg.err)
+		cmds = append(cmds, m.scheduleRefresh())
+	case RefreshedNews:
+		m.shared.offlineSince = time.Time{}
+		cmds = append(cmds, m.scheduleRefresh())
+		if !m.ready {
+			// the initial load failed, start with the refreshed news
+			m.loadingError = nil
+			cmds = append(cmds, func() tea.Msg { return tagesschau.News(msg) })
+			break
+		}
+		for _, article := range m.markNewArticles(tagesschau.News(msg)) {
+			if article.Breaking {
+				cmds = append(cmds, m.notification.Show(article))
+				break
+			}
+		}
+		news = tagesschau.News(msg)
+		m.loadThumbnails(news)
 	case UpdatedArticle:
-		article := tagesschau.Article(msg)
-		if m.shared.config.Settings.PreloadThumbnails {
-			go m.shared.imageCache.LoadThumbnail(article)
+		m.shared.activeArticle = tagesschau.Article(msg)
+		delete(m.shared.newArticleIDs, m.shared.activeArticle.ID)
+	case MarkedRead:
+		cmds = append(cmds, m.scheduleReadSave())
+	case SaveReadArticles:
+		// only the last scheduled save is performed
+		if msg.id == m.readSaves {
+			cmds = append(cmds, saveReadArticles(m.shared.read))
 		}
-		m.shared.activeArticle = article
+	case OpenMedia:
+		m.openMedia(tagesschau.Media(msg))
+	case OpenLink:
+		m.opener.OpenUrl(util.TypeHTML, string(msg))
+	case SelectedRegions:
+		m.shared.regions = msg.regions
+		cmds = append(cmds, saveRegions(msg.regions))
 	case tea.KeyMsg:
+		if m.regionPicker.IsVisible() {
+			// the dialog captures all keys while it is shown
+			m.regionPicker, cmd = m.regionPicker.Update(msg)
+			return m, cmd
+		}
+
 		if m.shared.mode != NORMAL_MODE {
 			break
 		}
@@ -132,15 +371,20 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 
 		switch {
+		case key.Matches(msg, m.shared.keymap.regions):
+			m.regionPicker.Show()
+			return m, nil
+		case key.Matches(msg, m.shared.keymap.bookmark):
+			if !m.shared.activeArticle.IsEmptyArticle() {
+				m.shared.bookmarks.Toggle(m.shared.activeArticle)
+				cmds = append(cmds, saveBookmarks(m.shared.bookmarks))
+			}
 		case key.Matches(msg, m.shared.keymap.open):
 			m.opener.OpenUrl(util.TypeHTML, m.shared.activeArticle.URL)
 		case key.Matches(msg, m.shared.keymap.video):
 			m.opener.OpenUrl(util.TypeVideo, m.shared.activeArticle.Video.VideoVariants.Big)
 		case key.Matches(msg, m.shared.keymap.shortNews):
-			url, err := tagesschau.GetShortNewsURL()
-			if err == nil {
-				m.opener.OpenUrl(util.TypeVideo, url)
-			}
+			cmds = append(cmds, loadShortNews(m.shared.client))
 		}
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
@@ -157,6 +401,9 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	m.helper, cmd = m.helper.Update(msg)
 	cmds = append(cmds, cmd)
 
+	m.notification, cmd = m.notification.Update(msg)
+	cmds = append(cmds, cmd)
+
 	m.navigator, cmd = m.navigator.Update(msg)
 	cmds = append(cmds, cmd)
 
@@ -171,16 +418,71 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 	}
 
+	cmds = append(cmds, m.markActiveArticleRead())
+
 	return m, tea.Batch(cmds...)
 }
 
+// markActiveArticleRead marks the active article as read once it is shown in the focused or fullscreen reader,
+// merely selecting it within a list does not count as reading it
+func (m *Model) markActiveArticleRead() tea.Cmd {
+	article := m.shared.activeArticle
+	if article.IsEmptyArticle() || m.shared.read.Contains(article.ID) {
+		return nil
+	}
+	viewer := m.viewManager.activeViewer()
+	if viewer.ViewerType() != VT_TEXT || !(viewer.IsFocused() || viewer.IsFullScreen()) {
+		return nil
+	}
+	m.shared.read.Add(article.ID)
+	return m.scheduleReadSave()
+}
+
+func (m Model) openMedia(media tagesschau.Media) {
+	if media.URL == "" {
+		return
+	}
+	switch media.Type {
+	case tagesschau.MT_VIDEO:
+		m.opener.OpenUrl(util.TypeVideo, media.URL)
+	case tagesschau.MT_AUDIO:
+		m.opener.OpenUrl(util.TypeAudio, media.URL)
+	case tagesschau.MT_GALLERY:
+		m.opener.OpenUrl(util.TypeImage, media.URL)
+	default:
+		m.opener.OpenUrl(util.TypeHTML, media.URL)
+	}
+}
+
+// markNewArticles remembers and returns all articles that were not part of the previous news
+func (m Model) markNewArticles(refreshed tagesschau.News) []tagesschau.Article {
+	known := make(map[string]bool)
+	for _, article := range append(news.NationalNews, news.RegionalNews...) {
+		known[article.ID] = true
+	}
+	var articles []tagesschau.Article
+	for _, article := range append(refreshed.NationalNews, refreshed.RegionalNews...) {
+		if !known[article.ID] {
+			m.shared.newArticleIDs[article.ID] = true
+			articles = append(articles, article)
+		}
+	}
+	return articles
+}
+
 func (m Model) View() string {
-	if m.loadingFailed {
-		content := "Laden der Nachrichten fehlgeschlagen... press q to quit"
+	if m.loadingError != nil {
+		catalog := m.shared.catalog
+		content := catalog.T(i18n.LoadingNewsFailed, errorText(catalog, m.loadingError)) + "\n\n" + catalog.T(i18n.QuitHint)
 		return m.shared.style.ScreenCenteredStyle(m.width, m.height).Render(content)
 	}
 	if !m.ready {
-		content := fmt.Sprintf("%s Lade Nachrichten... press q to quit", m.spinner.View())
+		catalog := m.shared.catalog
+		status := ""
+		if m.retryStatus.attempt > 0 {
+			status = catalog.T(i18n.RetryingNews, m.retryStatus.attempt, m.retryStatus.attempts) + " "
+		}
+		content := fmt.Sprintf("%s %s %s%s", m.spinner.View(), catalog.T(i18n.LoadingNews), status, catalog.T(i18n.QuitHint))
 		return m.shared.style.ScreenCenteredStyle(m.width, m.height).Render(content)
 	}
 
@@ -195,13 +497,27 @@ func (m Model) View() string {
 		helperHeight = 0
 	}
 
-	m.navigator.SetDims(navigatorWidth, m.height-helperHeight)
+	m.notification.SetWidth(m.width)
+	notification := m.notification.View()
+
+	notificationHeight := lipgloss.Height(notification)
+	if !m.notification.IsVisible() {
+		notificationHeight = 0
+	}
+
+	m.navigator.SetDims(navigatorWidth, m.height-helperHeight-notificationHeight)
 	navigator := m.navigator.View()
 
-	m.viewManager.SetDims(m.width, m.height-helperHeight, lipgloss.Width(navigator))
+	m.viewManager.SetDims(m.width, m.height-helperHeight-notificationHeight, lipgloss.Width(navigator))
 	viewer := m.viewManager.View()
 
 	view := lipgloss.JoinHorizontal(lipgloss.Top, navigator, viewer)
+	if m.regionPicker.IsVisible() {
+		view = lipgloss.Place(lipgloss.Width(view), lipgloss.Height(view), lipgloss.Center, lipgloss.Center, m.regionPicker.View())
+	}
+	if m.notification.IsVisible() {
+		view = lipgloss.JoinVertical(lipgloss.Left, notification, view)
+	}
 	if m.helper.IsVisible() {
 		view = lipgloss.JoinVertical(lipgloss.Center, view, help)
 	}
@@ -1,7 +1,17 @@
 package tui
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"image"
+	"net"
+	nethttp "net/http"
+	"time"
+
 	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/zMoooooritz/nachrichten/pkg/http"
+	"github.com/zMoooooritz/nachrichten/pkg/i18n"
 	"github.com/zMoooooritz/nachrichten/pkg/tagesschau"
 )
 
@@ -11,8 +21,120 @@ func NewDotSpinner() spinner.Model {
 	return s
 }
 
-type LoadingNewsFailed struct{}
-type LoadingArticlesFailed struct{}
+type LoadingNewsFailed struct {
+	err error
+}
+type RetryingNews struct {
+	attempt  int
+	attempts int
+}
+type OfflineNews struct {
+	news      tagesschau.News
+	timestamp time.Time
+}
+type RefreshNews struct{}
+type RefreshedNews tagesschau.News
+type RefreshingNewsFailed struct {
+	err error
+}
+type DismissNotification struct {
+	generation int
+}
+type SilenceBell struct {
+	generation int
+}
+type SelectArticle struct {
+	id string
+}
+type LoadingArticlesFailed struct {
+	err error
+}
+type InvalidSearchQuery struct {
+	err error
+}
+type LoadingMoreArticlesFailed struct {
+	err error
+}
+type LoadedRessort struct {
+	ressort  tagesschau.Ressort
+	articles []tagesschau.Article
+}
+type LoadingRessortFailed struct {
+	ressort tagesschau.Ressort
+	err     error
+}
+type SelectedRegions struct {
+	regions []tagesschau.RegionID
+}
+type LoadedRegionalNews struct {
+	regions  []tagesschau.RegionID
+	articles []tagesschau.Article
+}
 
 type UpdatedArticle tagesschau.Article
+type LoadedRelatedArticle tagesschau.Article
+type LoadingRelatedArticleFailed struct {
+	err error
+}
+type LoadedImage struct {
+	id    string
+	image image.Image
+}
 type ShowTextViewer struct{}
+type OpenMedia tagesschau.Media
+type OpenLink string
+type LoadedLinkedArticle tagesschau.Article
+type RestoreHistory HistoryEntry
+type UpdatedBookmarks struct{}
+type MarkedRead struct{}
+type SaveReadArticles struct {
+	id int
+}
+
+// errorText translates errors of the fetch layer into a message that can be shown to the user
+func errorText(catalog i18n.Catalog, err error) string {
+	var (
+		statusErr      *http.StatusError
+		contentTypeErr *http.ContentTypeError
+		bodyErr        *http.BodyTooLargeError
+		syntaxErr      *json.SyntaxError
+		typeErr        *json.UnmarshalTypeError
+		netErr         net.Error
+	)
+
+	switch {
+	case errors.As(err, &statusErr):
+		return catalog.T(i18n.ErrorStatus, statusErr.StatusCode, nethttp.StatusText(statusErr.StatusCode))
+	case errors.As(err, &contentTypeErr):
+		return catalog.T(i18n.ErrorContentType, contentTypeErr.ContentType)
+	case errors.As(err, &bodyErr):
+		return catalog.T(i18n.ErrorBodyTooLarge)
+	case errors.As(err, &syntaxErr), errors.As(err, &typeErr):
+		return catalog.T(i18n.ErrorUnreadable)
+	case errors.Is(err, context.DeadlineExceeded):
+		return catalog.T(i18n.ErrorTimeout)
+	case errors.As(err, &netErr):
+		if netErr.Timeout() {
+			return catalog.T(i18n.ErrorTimeout)
+		}
+		return catalog.T(i18n.ErrorNoConnection)
+	default:
+		return catalog.T(i18n.ErrorUnknown)
+	}
+}
+
+// queryErrorText translates errors of the search input
+func queryErrorText(catalog i18n.Catalog, err error) string {
+	var queryErr *tagesschau.QueryError
+	if !errors.As(err, &queryErr) {
+		return err.Error()
+	}
+	switch queryErr.Kind {
+	case tagesschau.UnknownSortOrder:
+		return catalog.T(i18n.UnknownSortOrder, queryErr.Value)
+	case tagesschau.InvalidDate:
+		return catalog.T(i18n.InvalidDate, queryErr.Value)
+	default:
+		return catalog.T(i18n.InvalidDateRange)
+	}
+}
@@ -83,11 +83,29 @@ func (v *ViewManager) Update(msg tea.Msg) (*ViewManager, tea.Cmd) {
 	switch msg := msg.(type) {
 	case ShowTextViewer:
 		v.showViewer(VT_TEXT)
+	case LoadedRelatedArticle, LoadedLinkedArticle:
+		v.shared.history.Visit(v.historyEntry())
+		for _, viewer := range v.viewers {
+			viewer.GotoTop()
+		}
+	case RestoreHistory:
+		v.showViewer(msg.viewer)
+		for _, viewer := range v.viewers {
+			viewer.SetScrollOffset(msg.offsets[viewer.ViewerType()])
+		}
 	case tea.KeyMsg:
 		if v.shared.mode == INSERT_MODE {
 			break
 		}
 		switch {
+		case key.Matches(msg, v.shared.keymap.back):
+			if entry, ok := v.shared.history.Back(v.historyEntry()); ok {
+				cmds = append(cmds, restoreHistory(entry))
+			}
+		case key.Matches(msg, v.shared.keymap.forward):
+			if entry, ok := v.shared.history.Forward(v.historyEntry()); ok {
+				cmds = append(cmds, restoreHistory(entry))
+			}
 		case key.Matches(msg, v.shared.keymap.article):
 			v.showViewer(VT_TEXT)
 		case key.Matches(msg, v.shared.keymap.image):
@@ -107,6 +125,27 @@ func (v *ViewManager) Update(msg tea.Msg) (*ViewManager, tea.Cmd) {
 	return v, tea.Batch(cmds...)
 }
 
+// historyEntry captures the current article and the scroll position of all viewers
+func (v ViewManager) historyEntry() HistoryEntry {
+	offsets := make(map[ViewerType]int)
+	for _, viewer := range v.viewers {
+		offsets[viewer.ViewerType()] = viewer.ScrollOffset()
+	}
+	return HistoryEntry{
+		article: v.shared.activeArticle,
+		viewer:  v.activeViewer().ViewerType(),
+		offsets: offsets,
+	}
+}
+
+// restoreHistory shows the article of the entry before the viewers are restored
+func restoreHistory(entry HistoryEntry) tea.Cmd {
+	return tea.Sequence(
+		func() tea.Msg { return UpdatedArticle(entry.article) },
+		func() tea.Msg { return RestoreHistory(entry) },
+	)
+}
+
 func (v ViewManager) View() string {
 	return v.viewers[v.activeViewerIndex].View()
 }
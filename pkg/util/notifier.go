@@ -0,0 +1,54 @@
+package util
+
+import (
+	"fmt"
+	"os/exec"
+	"runtime"
+
+	"github.com/zMoooooritz/nachrichten/pkg/config"
+)
+
+// Notifier emits desktop notifications through the configured application,
+// the args $title, $body and $ are replaced by the title, text and url of the notification
+type Notifier struct {
+	app config.Application
+}
+
+func NewNotifier(app config.Application) Notifier {
+	return Notifier{
+		app: app,
+	}
+}
+
+func (n Notifier) Notify(title, body, url string) error {
+	if n.app.Path == "" || len(n.app.Args) == 0 {
+		return defaultNotify(title, body)
+	}
+
+	args := make([]string, len(n.app.Args))
+	for i, arg := range n.app.Args {
+		switch arg {
+		case "$title":
+			args[i] = title
+		case "$body":
+			args[i] = body
+		case "$":
+			args[i] = url
+		default:
+			args[i] = arg
+		}
+	}
+	return exec.Command(n.app.Path, args...).Run()
+}
+
+func defaultNotify(title, body string) error {
+	switch runtime.GOOS {
+	case "darwin":
+		script := fmt.Sprintf("display notification %q with title %q", body, title)
+		return exec.Command("osascript", "-e", script).Run()
+	case "windows":
+		return fmt.Errorf("no default notification application available on %s", runtime.GOOS)
+	default: // "linux", "freebsd", "openbsd", "netbsd"
+		return exec.Command("notify-send", title, body).Run()
+	}
+}
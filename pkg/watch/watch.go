@@ -0,0 +1,117 @@
+package watch
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/zMoooooritz/nachrichten/pkg/config"
+	"github.com/zMoooooritz/nachrichten/pkg/i18n"
+	"github.com/zMoooooritz/nachrichten/pkg/storage"
+	"github.com/zMoooooritz/nachrichten/pkg/tagesschau"
+	"github.com/zMoooooritz/nachrichten/pkg/util"
+)
+
+const (
+	notifiedRetention time.Duration = 30 * 24 * time.Hour
+	minInterval       time.Duration = 30 * time.Second
+)
+
+// Watcher polls the homepage and notifies about new breaking or regional news,
+// the IDs of all seen articles are persisted to avoid duplicate notifications across restarts
+type Watcher struct {
+	client   *tagesschau.Client
+	notifier util.Notifier
+	notified *storage.IDSet
+	settings config.Watch
+	catalog  i18n.Catalog
+	verbose  bool
+}
+
+func NewWatcher(client *tagesschau.Client, notifier util.Notifier, notified *storage.IDSet, settings config.Watch, language string, verbose bool) *Watcher {
+	return &Watcher{
+		client:   client,
+		notifier: notifier,
+		notified: notified,
+		settings: settings,
+		catalog:  i18n.NewCatalog(i18n.DetectLanguage(language)),
+		verbose:  verbose,
+	}
+}
+
+// Run polls until the context is cancelled
+func (w *Watcher) Run(ctx context.Context) error {
+	// without any history every current article would be considered new
+	seed := w.notified.Len() == 0
+
+	for {
+		err := w.poll(ctx, seed)
+		if err != nil {
+			util.Logger.Println(err)
+		} else {
+			seed = false
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(max(w.settings.Interval, minInterval)):
+		}
+	}
+}
+
+func (w *Watcher) poll(ctx context.Context, seed bool) error {
+	news, err := w.client.LoadNews(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, article := range append(news.NationalNews, news.RegionalNews...) {
+		if w.notified.Contains(article.ID) {
+			continue
+		}
+
+		// every seen article is remembered so that it is only considered once
+		w.notified.Add(article.ID)
+		if seed {
+			continue
+		}
+
+		title, matches := w.match(article)
+		if !matches {
+			continue
+		}
+
+		err := w.notifier.Notify(title, article.Title()+"\n"+article.Desc, article.URL)
+		if err != nil {
+			util.Logger.Println(err)
+		}
+		if w.verbose {
+			fmt.Printf("%s %s: %s\n", time.Now().Format(time.TimeOnly), title, article.Title())
+		} else {
+			util.Logger.Printf("%s: %s\n", title, article.Title())
+		}
+	}
+
+	w.notified.Prune(notifiedRetention)
+	return w.notified.Save()
+}
+
+// match checks whether the user is interested in the article and returns the notification title
+func (w *Watcher) match(article tagesschau.Article) (string, bool) {
+	if w.settings.Breaking && article.Breaking {
+		return w.catalog.T(i18n.BreakingNews), true
+	}
+	for _, regionID := range article.RegionIDs {
+		for _, wanted := range w.settings.Regions {
+			if int(regionID) == wanted {
+				name := w.catalog.RegionName(regionID)
+				if name == "" {
+					name = article.Topline
+				}
+				return name, true
+			}
+		}
+	}
+	return "", false
+}